@@ -0,0 +1,196 @@
+package core
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	ArchiveSeparator = "!/"
+
+	DefaultArchiveDepth   = 2
+	DefaultArchiveMaxSize = 10 * 1024 * 1024
+)
+
+var zipArchiveExtensions = []string{".zip", ".jar", ".war", ".ear", ".aar", ".apk"}
+var tarArchiveExtensions = []string{".tar"}
+var gzipTarArchiveExtensions = []string{".tar.gz", ".tgz"}
+
+var ErrArchiveSizeExceeded = errors.New("archive size limit exceeded")
+
+// ArchiveEntry represents a single file extracted from an archive. Err is
+// set instead of Content when the entry couldn't be read.
+type ArchiveEntry struct {
+	Path    string
+	Content []byte
+	Err     error
+}
+
+// ArchiveLimits bounds how deep and how much an archive is extracted
+type ArchiveLimits struct {
+	Depth   int
+	MaxSize int64
+}
+
+type archiveExtractor struct {
+	limits    ArchiveLimits
+	remaining int64
+	entries   []ArchiveEntry
+}
+
+// IsArchive reports whether the path has an extension of a supported archive format
+func IsArchive(path string) bool {
+	return archiveFormat(path) != ""
+}
+
+// ArchiveOuterPath returns the repository path of the outermost archive
+// containing the given entry path, or the path itself if it is not an entry.
+func ArchiveOuterPath(path string) string {
+	if i := strings.Index(path, ArchiveSeparator); i != -1 {
+		return path[:i]
+	}
+	return path
+}
+
+// ExtractArchive recursively extracts all regular files from an archive.
+// Entry paths are prefixed with the archive path and ArchiveSeparator,
+// e.g. lib/app.jar!/config/application.properties. Extraction stops once
+// the total uncompressed size reaches limits.MaxSize, in which case the
+// entries extracted so far are returned along with ErrArchiveSizeExceeded.
+// Entries that can't be read are returned with their error.
+func ExtractArchive(path string, data []byte, limits ArchiveLimits) ([]ArchiveEntry, error) {
+	e := &archiveExtractor{
+		limits:    limits,
+		remaining: limits.MaxSize,
+	}
+	err := e.extract(path, data, 1)
+	return e.entries, err
+}
+
+func (e *archiveExtractor) extract(path string, data []byte, depth int) error {
+	switch archiveFormat(path) {
+	case "zip":
+		return e.extractZip(path, data, depth)
+	case "tar":
+		return e.extractTar(path, bytes.NewReader(data), depth)
+	case "tgz":
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		return e.extractTar(path, gz, depth)
+	}
+	return nil
+}
+
+func (e *archiveExtractor) extractZip(path string, data []byte, depth int) error {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			e.skip(path, file.Name, err)
+			continue
+		}
+		err = e.add(path, file.Name, rc, depth)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *archiveExtractor) extractTar(path string, r io.Reader, depth int) error {
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := e.add(path, header.Name, reader, depth); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *archiveExtractor) add(archivePath string, name string, r io.Reader, depth int) error {
+	if e.remaining <= 0 {
+		return ErrArchiveSizeExceeded
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(r, e.remaining+1))
+	if err != nil {
+		e.skip(archivePath, name, err)
+		return nil
+	}
+	if int64(len(content)) > e.remaining {
+		e.remaining = 0
+		return ErrArchiveSizeExceeded
+	}
+	e.remaining -= int64(len(content))
+
+	entryPath := archiveEntryPath(archivePath, name)
+	e.entries = append(e.entries, ArchiveEntry{
+		Path:    entryPath,
+		Content: content,
+	})
+
+	if depth < e.limits.Depth && IsArchive(name) {
+		err := e.extract(entryPath, content, depth+1)
+		if err == ErrArchiveSizeExceeded {
+			return err
+		}
+	}
+	return nil
+}
+
+// skip records an entry that couldn't be read, so it's reported instead of
+// silently left out
+func (e *archiveExtractor) skip(archivePath string, name string, err error) {
+	e.entries = append(e.entries, ArchiveEntry{
+		Path: archiveEntryPath(archivePath, name),
+		Err:  err,
+	})
+}
+
+func archiveEntryPath(archivePath string, name string) string {
+	return archivePath + ArchiveSeparator + strings.TrimPrefix(name, "/")
+}
+
+func archiveFormat(path string) string {
+	path = strings.ToLower(path)
+	for _, ext := range gzipTarArchiveExtensions {
+		if strings.HasSuffix(path, ext) {
+			return "tgz"
+		}
+	}
+	for _, ext := range tarArchiveExtensions {
+		if strings.HasSuffix(path, ext) {
+			return "tar"
+		}
+	}
+	for _, ext := range zipArchiveExtensions {
+		if strings.HasSuffix(path, ext) {
+			return "zip"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+)
+
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+func zipArchive(t *testing.T, files ...archiveFile) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range files {
+		f, err := w.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(file.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func tarArchive(t *testing.T, files ...archiveFile) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, file := range files {
+		if err := w.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(file.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractArchive(t *testing.T) {
+	secret := archiveFile{"config/application.properties", []byte("password=hunter2")}
+	inner := zipArchive(t, secret)
+	innermost := zipArchive(t, archiveFile{"lib/inner.jar", inner})
+	bomb := zipArchive(t, archiveFile{"zeros", make([]byte, 10*1024*1024)})
+
+	tests := []struct {
+		name   string
+		path   string
+		data   []byte
+		limits ArchiveLimits
+		paths  []string
+		err    error
+	}{
+		{
+			name:   "zip",
+			path:   "app.zip",
+			data:   inner,
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1024},
+			paths:  []string{"app.zip!/config/application.properties"},
+		},
+		{
+			name:   "tar",
+			path:   "app.tar",
+			data:   tarArchive(t, secret, archiveFile{"/etc/passwd", []byte("root")}),
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1024},
+			paths:  []string{"app.tar!/config/application.properties", "app.tar!/etc/passwd"},
+		},
+		{
+			name:   "tar.gz",
+			path:   "app.tar.gz",
+			data:   gzipData(t, tarArchive(t, secret)),
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1024},
+			paths:  []string{"app.tar.gz!/config/application.properties"},
+		},
+		{
+			name:   "nested within depth",
+			path:   "app.war",
+			data:   zipArchive(t, archiveFile{"WEB-INF/lib/lib.jar", inner}),
+			limits: ArchiveLimits{Depth: 2, MaxSize: 4096},
+			paths:  []string{"app.war!/WEB-INF/lib/lib.jar", "app.war!/WEB-INF/lib/lib.jar!/config/application.properties"},
+		},
+		{
+			name:   "nested beyond depth",
+			path:   "app.war",
+			data:   zipArchive(t, archiveFile{"WEB-INF/lib/lib.jar", innermost}),
+			limits: ArchiveLimits{Depth: 2, MaxSize: 4096},
+			paths:  []string{"app.war!/WEB-INF/lib/lib.jar", "app.war!/WEB-INF/lib/lib.jar!/lib/inner.jar"},
+		},
+		{
+			name:   "zip bomb",
+			path:   "bomb.zip",
+			data:   bomb,
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1024 * 1024},
+			err:    ErrArchiveSizeExceeded,
+		},
+		{
+			name:   "nested zip bomb",
+			path:   "app.jar",
+			data:   zipArchive(t, secret, archiveFile{"bomb.zip", bomb}),
+			limits: ArchiveLimits{Depth: 2, MaxSize: 1024 * 1024},
+			paths:  []string{"app.jar!/config/application.properties", "app.jar!/bomb.zip"},
+			err:    ErrArchiveSizeExceeded,
+		},
+		{
+			name:   "size limit across entries",
+			path:   "app.zip",
+			data:   zipArchive(t, archiveFile{"a", make([]byte, 600)}, archiveFile{"b", make([]byte, 600)}),
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1000},
+			paths:  []string{"app.zip!/a"},
+			err:    ErrArchiveSizeExceeded,
+		},
+		{
+			name:   "not an archive",
+			path:   "app.txt",
+			data:   []byte("password=hunter2"),
+			limits: ArchiveLimits{Depth: 1, MaxSize: 1024},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := ExtractArchive(test.path, test.data, test.limits)
+			if err != test.err {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+			var paths []string
+			for _, entry := range entries {
+				paths = append(paths, entry.Path)
+			}
+			if !reflect.DeepEqual(paths, test.paths) {
+				t.Errorf("got entries %q, want %q", paths, test.paths)
+			}
+		})
+	}
+}
+
+func TestExtractArchiveUnreadableEntry(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range []archiveFile{{"a.env", []byte("password=hunter2")}, {"b.env", []byte("token=abc")}} {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(file.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Corrupting the stored content of the first entry makes its checksum fail
+	data := bytes.Replace(buf.Bytes(), []byte("hunter2"), []byte("hunter3"), 1)
+
+	entries, err := ExtractArchive("app.zip", data, ArchiveLimits{Depth: 1, MaxSize: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].Path != "app.zip!/a.env" || entries[0].Err != zip.ErrChecksum {
+		t.Errorf("got %s with error %v, want app.zip!/a.env with %v", entries[0].Path, entries[0].Err, zip.ErrChecksum)
+	}
+	if entries[1].Err != nil || string(entries[1].Content) != "token=abc" {
+		t.Errorf("readable entry: got %q, %v", entries[1].Content, entries[1].Err)
+	}
+}
+
+func TestArchiveOuterPath(t *testing.T) {
+	tests := map[string]string{
+		"app.war":                 "app.war",
+		"app.war!/lib/a.jar":      "app.war",
+		"app.war!/lib/a.jar!/a.c": "app.war",
+	}
+	for path, want := range tests {
+		if got := ArchiveOuterPath(path); got != want {
+			t.Errorf("%s: got %s, want %s", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	WebTokenEnvVariable    = "GITROB_WEB_TOKEN"
+	WebUsernameEnvVariable = "GITROB_WEB_USERNAME"
+	WebPasswordEnvVariable = "GITROB_WEB_PASSWORD"
+
+	AuthRealm      = "Gitrob"
+	CsrfCookieName = "gitrob_csrf"
+	CsrfHeaderName = "X-CSRF-Token"
+)
+
+// WebAuth holds the credentials accepted by the web interface: a bearer
+// token, a username and password, and the users of an htpasswd file
+type WebAuth struct {
+	Token    string
+	Username string
+	Password string
+	Users    map[string]string // Password hashes of htpasswd users by name
+}
+
+// LoadWebAuth reads the bearer token and basic auth credentials from the
+// environment and the users of the htpasswd file, if given
+func LoadWebAuth(htpasswdFile string) (*WebAuth, error) {
+	auth := &WebAuth{
+		Token:    os.Getenv(WebTokenEnvVariable),
+		Username: os.Getenv(WebUsernameEnvVariable),
+		Password: os.Getenv(WebPasswordEnvVariable),
+	}
+	if (auth.Username == "") != (auth.Password == "") {
+		return nil, fmt.Errorf("%s and %s must be set together", WebUsernameEnvVariable, WebPasswordEnvVariable)
+	}
+	if htpasswdFile != "" {
+		users, err := loadHtpasswd(htpasswdFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.Users = users
+	}
+	return auth, nil
+}
+
+// Enabled reports whether any credentials are configured
+func (a *WebAuth) Enabled() bool {
+	return a.Token != "" || a.Username != "" || len(a.Users) > 0
+}
+
+// Authenticate checks the credentials of a request. Bearer reports whether
+// the request was authenticated with the token in an Authorization header,
+// which browsers don't send on their own. Browsers can use the token as the
+// password of basic auth, with any username.
+func (a *WebAuth) Authenticate(r *http.Request) (ok bool, bearer bool) {
+	header := r.Header.Get("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		token := strings.TrimPrefix(header, "Bearer ")
+		return a.Token != "" && secureCompare(token, a.Token), true
+	}
+	username, password, found := r.BasicAuth()
+	if !found {
+		return false, false
+	}
+	if a.Token != "" && secureCompare(password, a.Token) {
+		return true, false
+	}
+	if a.Username != "" && secureCompare(username, a.Username) && secureCompare(password, a.Password) {
+		return true, false
+	}
+	if hash, exists := a.Users[username]; exists {
+		return checkHtpasswd(hash, password), false
+	}
+	return false, false
+}
+
+// Middleware rejects requests without valid credentials, asking browsers to
+// prompt for them
+func (a *WebAuth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, bearer := a.Authenticate(c.Request)
+		if !ok {
+			c.Header("WWW-Authenticate", fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", AuthRealm))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"message": "Authentication required",
+			})
+			return
+		}
+		c.Set("bearer", bearer)
+		c.Next()
+	}
+}
+
+// CsrfMiddleware protects mutating requests from other sites with a double
+// submit token: the token is set as a cookie, which only pages of the web
+// interface can read and send back in the X-CSRF-Token header. Requests
+// authenticated with a bearer token can't be forged by other sites, and
+// don't need the header.
+func CsrfMiddleware(secure bool) gin.HandlerFunc {
+	token := randomToken()
+	return func(c *gin.Context) {
+		if cookie, err := c.Cookie(CsrfCookieName); err != nil || cookie != token {
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     CsrfCookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   secure,
+				SameSite: http.SameSiteStrictMode,
+			})
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if c.GetBool("bearer") || secureCompare(c.GetHeader(CsrfHeaderName), token) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"message": fmt.Sprintf("Missing or invalid %s header", CsrfHeaderName),
+		})
+	}
+}
+
+// loadHtpasswd reads the users of an htpasswd file with bcrypt or SHA1
+// password hashes, as written by htpasswd -B or -s
+func loadHtpasswd(location string) (map[string]string, error) {
+	f, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	users := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%s:%d: expected user:password-hash", location, line)
+		}
+		if !strings.HasPrefix(parts[1], "$2") && !strings.HasPrefix(parts[1], "{SHA}") {
+			return nil, fmt.Errorf("%s:%d: unsupported password hash for user %s, use bcrypt (htpasswd -B) or SHA1 (htpasswd -s)", location, line, parts[0])
+		}
+		users[parts[0]] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func checkHtpasswd(hash string, password string) bool {
+	if strings.HasPrefix(hash, "{SHA}") {
+		sum := sha1.Sum([]byte(password))
+		return secureCompare(strings.TrimPrefix(hash, "{SHA}"), base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func secureCompare(given string, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
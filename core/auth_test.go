@@ -0,0 +1,192 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func testWebAuth(t *testing.T) *WebAuth {
+	hash, err := bcrypt.GenerateFromPassword([]byte("bcrypt-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &WebAuth{
+		Token:    "token",
+		Username: "admin",
+		Password: "admin-password",
+		Users: map[string]string{
+			"alice": string(hash),
+			"bob":   "{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=", // password
+		},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	auth := testWebAuth(t)
+	tests := []struct {
+		name     string
+		header   string
+		username string
+		password string
+		ok       bool
+		bearer   bool
+	}{
+		{name: "no credentials"},
+		{name: "bearer token", header: "Bearer token", ok: true, bearer: true},
+		{name: "wrong bearer token", header: "Bearer nope", bearer: true},
+		{name: "empty bearer token", header: "Bearer ", bearer: true},
+		{name: "token as password", username: "anyone", password: "token", ok: true},
+		{name: "username and password", username: "admin", password: "admin-password", ok: true},
+		{name: "wrong password", username: "admin", password: "token2"},
+		{name: "bcrypt user", username: "alice", password: "bcrypt-password", ok: true},
+		{name: "bcrypt user with wrong password", username: "alice", password: "admin-password"},
+		{name: "SHA1 user", username: "bob", password: "password", ok: true},
+		{name: "SHA1 user with wrong password", username: "bob", password: "Password"},
+		{name: "unknown user", username: "carol", password: "password"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				r.Header.Set("Authorization", test.header)
+			}
+			if test.username != "" {
+				r.SetBasicAuth(test.username, test.password)
+			}
+			ok, bearer := auth.Authenticate(r)
+			if ok != test.ok || bearer != test.bearer {
+				t.Errorf("got ok %v, bearer %v, want ok %v, bearer %v", ok, bearer, test.ok, test.bearer)
+			}
+		})
+	}
+
+	// Without a token, an empty bearer token must not match it
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer ")
+	if ok, _ := (&WebAuth{Username: "admin", Password: "admin-password"}).Authenticate(r); ok {
+		t.Errorf("empty bearer token accepted without a token set")
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(testWebAuth(t).Middleware())
+	router.Use(CsrfMiddleware(false))
+	router.GET("/findings", func(c *gin.Context) { c.String(http.StatusOK, "findings") })
+	router.POST("/findings", func(c *gin.Context) { c.String(http.StatusOK, "updated") })
+
+	// Pages of the web interface get the CSRF token as a cookie
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/findings", nil)
+	r.SetBasicAuth("admin", "admin-password")
+	router.ServeHTTP(w, r)
+	var csrf *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == CsrfCookieName {
+			csrf = cookie
+		}
+	}
+	if csrf == nil || csrf.Value == "" {
+		t.Fatalf("got no %s cookie", CsrfCookieName)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		bearer bool
+		basic  bool
+		csrf   string
+		status int
+	}{
+		{name: "GET without credentials", method: http.MethodGet, status: http.StatusUnauthorized},
+		{name: "POST without credentials", method: http.MethodPost, csrf: csrf.Value, status: http.StatusUnauthorized},
+		{name: "GET with basic auth", method: http.MethodGet, basic: true, status: http.StatusOK},
+		{name: "POST with basic auth without token", method: http.MethodPost, basic: true, status: http.StatusForbidden},
+		{name: "POST with basic auth and wrong token", method: http.MethodPost, basic: true, csrf: "nope", status: http.StatusForbidden},
+		{name: "POST with basic auth and token", method: http.MethodPost, basic: true, csrf: csrf.Value, status: http.StatusOK},
+		{name: "POST with bearer token", method: http.MethodPost, bearer: true, status: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(test.method, "/findings", nil)
+			if test.basic {
+				r.SetBasicAuth("admin", "admin-password")
+			}
+			if test.bearer {
+				r.Header.Set("Authorization", "Bearer token")
+			}
+			if test.csrf != "" {
+				r.Header.Set(CsrfHeaderName, test.csrf)
+			}
+			router.ServeHTTP(w, r)
+			if w.Code != test.status {
+				t.Errorf("got status %d, want %d", w.Code, test.status)
+			}
+			if test.status == http.StatusUnauthorized && !strings.HasPrefix(w.Header().Get("WWW-Authenticate"), "Basic ") {
+				t.Errorf("got WWW-Authenticate %q, want a basic auth challenge", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestLoadHtpasswd(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		users []string
+		err   string
+	}{
+		{
+			name:  "bcrypt and SHA1",
+			data:  "# users\nalice:$2y$05$abcdefghijklmnopqrstuuabcdefghijklmnopqrstuvwxyz01234\n\n  bob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=  \n",
+			users: []string{"alice", "bob"},
+		},
+		{name: "MD5", data: "alice:$apr1$abc$def\n", err: ":1: unsupported password hash for user alice"},
+		{name: "crypt", data: "alice:$2y$05$x\nbob:abcdefgh\n", err: ":2: unsupported password hash"},
+		{name: "plain password", data: "alice:password\n", err: "unsupported password hash"},
+		{name: "no hash", data: "alice\n", err: ":1: expected user:password-hash"},
+		{name: "no user", data: ":{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n", err: "expected user:password-hash"},
+	}
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location := filepath.Join(dir, string(rune('a'+i)))
+			if err := ioutil.WriteFile(location, []byte(test.data), 0600); err != nil {
+				t.Fatal(err)
+			}
+			users, err := loadHtpasswd(location)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(users) != len(test.users) {
+				t.Fatalf("got %d users, want %d", len(users), len(test.users))
+			}
+			for _, user := range test.users {
+				if users[user] == "" {
+					t.Errorf("user %s is missing", user)
+				}
+			}
+		})
+	}
+
+	if _, err := loadHtpasswd(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("got no error loading a missing file")
+	}
+}
@@ -259,7 +259,7 @@ func staticImagesSpinnerGif() (*asset, error) {
 	return a, nil
 }
 
-var _staticIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5a\x6d\x6f\xdc\xb8\x11\xfe\x9e\x5f\x31\xc7\x62\x0f\x09\x70\x5a\x39\x35\x0e\x28\x1c\x69\xd1\x34\xce\x5d\x0c\x5c\x92\x43\xe2\x16\xe8\xa7\x05\x25\xce\x4a\x8c\x29\x52\x25\x29\xaf\xdd\xa2\xff\xfd\x40\x52\xd2\xea\x6d\x7d\x59\x27\x01\x02\x04\x6b\x91\x9c\x37\x3e\x1c\x0e\x67\xc8\x24\x3f\x30\x95\xdb\xfb\x1a\xa1\xb4\x95\xd8\x3c\x49\xdc\x1f\x10\x54\x16\x29\x41\x49\x36\x4f\x00\x92\x12\x29\x73\x1f\x00\x49\x85\x96\x42\x5e\x52\x6d\xd0\xa6\xa4\xb1\xbb\xe8\x6f\x64\x38\x24\x69\x85\x29\xb9\xe5\xb8\xaf\x95\xb6\x04\x72\x25\x2d\x4a\x9b\x92\x3d\x67\xb6\x4c\x19\xde\xf2\x1c\x23\xdf\xf8\x09\xb8\xe4\x96\x53\x11\x99\x9c\x0a\x4c\x9f\xff\x04\xa6\xd4\x5c\xde\x44\x56\x45\x3b\x6e\x53\xa9\x16\x44\x33\x34\xb9\xe6\xb5\xe5\x4a\x0e\xa4\xff\xca\xad\x56\xd9\x05\xfc\xde\x58\xcb\x65\x01\xb6\x44\x78\x5f\xa3\x84\x8f\xaa\xd1\x39\x02\x97\xf0\xfe\xe3\xd5\xbb\xeb\x05\x81\xb4\xb1\xa5\xd2\x03\x59\x6f\x79\x5e\x52\x14\xf0\x06\xa5\xe6\x37\x06\x25\x3c\xfd\x7b\xc5\xf3\xb2\x6b\x3e\x23\x9b\x27\x41\x8a\xe5\x56\xe0\x26\xe8\x4e\xe2\xd0\x6a\x87\x04\x97\x37\x50\x6a\xdc\xa5\x24\x36\xf6\x5e\xa0\x29\x11\xad\x89\x33\xa5\xac\xb1\x9a\xd6\xeb\xdc\x18\x02\x1a\x45\x4a\x0e\xe3\x9d\x79\xc7\xb8\x55\x8d\x92\xe7\x4a\xf2\xfc\x51\xec\x25\x2f\x4a\xc1\x8b\xd2\x3e\x8a\x9b\xd6\xb5\xe0\x39\x75\xc8\x1f\xe7\x4f\xe2\xe0\x2c\xee\x33\x53\xec\xbe\xc3\x43\xd2\x5b\xc8\x05\x35\x26\x25\x92\xde\x66\x54\x43\xf8\x13\xe1\x5d\x4d\x25\x8b\x2a\xd6\x75\x78\x03\x21\x2b\xc2\x47\x6b\x14\x40\xc2\x78\x2f\xc1\x2d\x15\xe5\x12\x75\x3f\x0a\x90\xd0\xb1\xfc\x28\xd3\x54\x32\xd2\x4d\x64\x48\xc9\xab\x02\x8c\xce\x53\x12\xf3\x8a\x16\x68\xe2\x42\xd5\x25\xea\xad\xb3\x7c\x5d\xcb\x82\x40\x70\x56\x72\x7e\x46\xa0\x44\x67\x46\x4a\xfe\x7a\x46\x3a\x05\x2c\xe2\x52\x70\x89\x51\x26\x54\x7e\x43\x80\x0a\x9b\x92\x81\x82\xce\x21\xe8\x40\x67\xd6\x58\xab\xe4\xc4\x44\xab\x8a\x42\xa0\x26\xe0\xf6\x5f\x4a\x02\x0d\x01\x46\x2d\x6d\xc7\xdc\x5c\x85\xa0\xb5\x41\x02\x54\x73\xda\xc2\x85\x2c\x25\x3b\x2a\xfa\x5e\x41\x33\xb7\x16\xd7\x9e\xc7\x01\xc9\x0b\xbf\x4e\x03\xa3\x00\x12\x53\xd3\x23\x16\x44\xce\xa9\xc8\x26\x89\x1d\xc9\xc0\xea\x38\x98\xd4\xaf\x41\xcc\xf8\x6d\xeb\x25\xb1\xa4\xb7\xdd\xe2\x56\x94\x4b\xd0\xca\x99\xeb\x3e\xc9\xf1\x75\x4a\x32\x0d\xf1\x68\x49\x39\x73\x3e\x44\xad\xd9\x2e\xae\xea\x60\xd5\x6b\xad\x0a\x8d\xce\xf1\xbc\xcf\xa5\x24\x2c\xcd\x05\x9c\x9f\xd5\x77\x2f\xc6\x53\x5d\x60\x8b\x9c\xd3\x0d\x1b\x91\xb1\x9a\xd7\xc8\xc6\x9d\x54\xf2\x8a\x5a\x64\xa4\x9d\x50\x37\x98\x51\x4d\xbc\xb1\x5d\xc7\xd6\xf7\xb4\xa6\x78\x87\xb9\x80\xe7\x67\x67\xab\x17\xed\x9a\xdc\x52\xd1\xa0\x54\xfb\x94\x3c\x3f\x3b\x1b\xf6\x55\x5c\xa6\x64\xdc\x43\xef\x02\xd5\xe6\x2a\x44\x44\xfe\x5f\x2e\x8b\xf5\x7a\x3d\x00\x7c\x82\xff\x0c\xcc\xf1\xa4\xb5\xda\x1f\x05\x24\x57\x22\x32\xd5\x68\x78\x42\x40\x35\x03\x8b\x77\x36\xca\x51\x5a\x6c\xe7\xed\x7a\xb7\x3b\x2e\x19\x97\x85\x99\x70\xcf\xf9\x23\xb7\xf9\x67\x54\xee\x2c\x39\x1f\x91\xf9\xa0\xb9\xa0\x60\xeb\x81\x21\x9b\xb3\x24\x2e\xcf\x17\xc4\xd4\x63\x29\x78\x67\x97\x84\xb8\xc3\x82\x6c\x7e\x69\x9b\x49\x5c\xcf\xcc\x1e\x23\xba\xd8\x35\xef\xf8\x6a\x60\x0a\xfc\x96\x48\x0a\xfc\x52\x18\x9d\x84\x0e\x43\x81\xdf\x1d\x80\xb9\xaa\x2a\x6e\xbf\x1d\x84\xad\xfc\x2f\x02\xb1\x93\x11\x60\x7c\x15\x5a\xdf\x1b\x90\x1a\x6b\x65\xb8\x55\x9a\x7f\x43\x87\x1c\x2a\xf9\x22\x48\x47\x82\x02\xae\x1f\x06\x5d\xdf\x1b\xb8\x96\xea\x02\xbf\xa1\x97\xb6\xf2\xbf\x08\xd2\x4e\x46\x40\xf3\x3a\xb4\xbe\x37\x20\x59\xa3\xe7\x59\xcd\xd7\x44\xb2\x53\xd0\x43\x79\x76\xe1\xff\x3d\x06\xd1\x5e\x56\x80\xf4\xb2\x6d\x7e\x1d\x4c\x47\xcd\xb6\x31\xce\xb0\xba\x96\xc1\xdc\xa9\x0d\x99\x0b\x2d\x70\xe9\x04\x4f\xc6\xb3\xeb\x8e\xcb\xa1\x7a\x2e\xeb\xc6\x76\xd3\xdd\x29\x5d\x45\x2e\x5b\xd3\x4a\xc0\xb0\x11\x99\x0a\x76\x42\x51\x1b\x69\x9f\xbb\xb7\x79\x6d\x40\xa6\x16\x34\xc7\x52\x09\x86\x3a\x25\x1f\x91\xea\xbc\x5c\xaf\xd7\x01\xb1\xfe\xc0\x36\xbe\x7f\x68\x9b\x87\xfe\xd0\xb4\x34\x13\xd8\x19\x12\x1a\xfe\xd7\xa9\x0e\x1f\xa5\xba\x45\xdd\x75\x86\x0c\x2f\x28\xf1\x5d\xcb\x19\x4c\x62\x0f\x25\xee\xa1\x4f\xcf\x56\xca\x96\x60\x72\x55\x87\xb4\x9c\x0c\x7d\x9a\xe6\xc1\x33\x5f\xe6\x61\x95\x6d\x79\x02\x73\x4d\x6d\x49\x36\xbf\x53\x5b\x9e\xc8\x18\x0e\x97\xee\x58\x39\x91\xb9\x0f\xa3\xf7\x83\xf8\x79\x3f\x17\x92\xc4\x63\x24\x1c\xc5\x04\xad\xc4\x86\x5a\x6f\x44\x34\xee\x4a\x62\x8f\xff\xc1\x69\x5b\xcf\xec\xca\x09\x57\x38\x6c\x92\x1f\xa2\x08\xe2\x75\x5f\x09\x40\x14\x75\x35\xc6\x4e\x29\x8b\xfa\xc1\x6a\x70\x18\x36\xc2\x77\xd5\xb8\x4c\x7e\x54\x24\x86\x7a\xb0\xb4\xb6\x36\x17\x71\x5c\x70\x5b\x36\xd9\x3a\x57\x55\x3c\x2c\xf1\x5d\xbf\x56\x19\x81\x10\x17\x53\xb2\xcd\x04\x95\x37\x64\x73\x28\xed\x80\x1b\xa0\xae\x74\xf8\x84\xb9\x85\xec\x7e\x2c\xfb\x22\x1e\xc9\x73\x0a\xe6\xc2\x66\x17\x0d\x5e\xee\x8f\x15\x67\x4c\xd9\x17\xa7\x1a\x1b\x73\x63\x1a\x34\xb1\xc4\xfd\x5c\x95\x5b\x5f\x6d\x81\x4a\xf0\x54\x83\xda\x74\x54\xd3\x75\x20\x87\x66\xb8\x68\x19\x6c\xe2\xd8\x62\x55\x0b\x6a\xdb\x98\xd9\xb5\xba\x3d\x75\xa8\xf2\x2c\x5b\xda\x1b\x87\x65\x58\x01\xdf\xc1\xd3\xb0\x57\x20\x4d\x81\xbc\x55\x8c\xef\xee\xc9\x33\xf8\x1f\xac\x8e\xd6\xac\x19\x65\x05\x82\xff\x8d\x6a\xcd\x2b\xea\x3c\xf7\xed\xfb\xcb\xab\x5f\xfe\x3d\xab\x5c\x57\xf0\x7f\x40\x61\x70\xaa\xe8\x4a\x1a\xd4\xf6\x04\x45\xa6\xc9\x73\x57\x74\x6e\x5e\x7d\x78\xfd\xf2\xfa\xf5\x67\x2b\xba\x44\x81\x16\x4f\x50\xc4\xa8\x2c\x5c\xed\x7b\xf9\xfa\xb7\xd7\x47\xf4\xac\x0e\x8b\x66\xd9\x11\xb0\x43\x2c\x49\x72\xc5\x70\xc1\xef\xff\x42\x36\xc9\x2a\x05\x5b\x72\xb3\x76\x91\x9b\x5a\x8b\xcc\xe5\xf6\x2e\xf8\x3c\x7d\x06\xab\xcd\xc8\x35\xbc\x94\x07\x94\x75\xf1\x27\xa8\xeb\xb5\x24\xab\x08\x42\x48\xfa\xa7\x16\xb0\xda\xb4\x57\x45\x52\xa9\x1a\xdd\x3e\x95\x4a\xe3\x0e\xb5\xbf\xf9\x98\x38\x6a\x6f\x5d\xa5\x18\x8a\xb5\x29\x95\xb6\x41\xd4\x1b\x6a\x0e\x16\x1e\x4c\x2b\x8f\x98\x36\x8c\x6e\x23\xc3\x0e\xa1\xee\x11\xc6\x0d\xd9\xdf\xef\x1d\xf9\x6a\x13\x8f\xbb\xdf\xd1\x0a\x7b\x2b\x3b\xf3\x92\x38\x6c\xa6\xc7\x6e\xad\x6d\xa5\x18\x15\x8b\x97\x61\x7e\x24\x72\x11\x79\x7c\x73\x52\xfe\x3c\xa6\x08\xc9\x8e\x9f\xc3\xe5\xe1\x0e\xd5\x5b\x5a\xfe\x3c\xbf\xa9\x1a\x5f\x49\x75\xc0\x0a\x65\xb0\xbd\xa0\x62\xdc\x54\xbc\x17\x3f\xbe\x88\x7a\xe5\xe9\xe6\x6e\xef\x69\x4a\xce\x18\xca\x94\x58\xed\x72\xac\x1f\x2d\xaf\xd0\xbc\x38\xe1\xea\x69\x69\xfa\x93\x84\xaf\x0d\x30\xde\x91\xb8\xb9\x46\x63\x3f\xa0\x83\x93\x3d\x7d\x36\xdf\x90\x03\x61\x54\xa0\x8b\x92\xee\x37\xda\x53\x2d\x5d\x50\x6b\xef\x81\x7c\x27\xd9\x24\xc6\x6a\x25\x8b\xcd\x3b\x65\x79\x8e\x17\x49\xdc\xb6\xe1\xba\xe4\x06\x5c\xc5\x0c\x42\xa9\x1b\x03\x56\x41\x86\x60\xd1\xf8\xfb\x68\x1d\xd4\xcf\x2e\x74\x46\xbb\x7a\x6c\x4b\x66\x65\x54\x68\xd5\xd4\xd0\x7f\x4d\xf3\xab\xd1\x34\x16\xd7\x6d\x90\x5c\x6d\x6f\x39\xee\xb7\x9a\xee\xc9\x40\x83\x97\x6d\x30\x57\x92\xf9\x68\xfa\x81\xee\xa7\xc8\x9f\x20\xbc\xc4\x3b\xd6\x54\xf5\x43\x0a\xde\xe0\x1d\x38\x9a\xb9\x96\x29\x34\xa3\x4c\xaf\x55\x13\x55\x68\x69\xe4\x47\x26\xf9\x9b\x9e\x26\x6f\xa5\xcf\xa7\x2e\x16\xd2\x19\xcb\xba\x78\xd5\xae\xdd\xf2\xb6\xee\x97\x36\x5e\xa6\xeb\xf7\x79\x4f\xb6\x8a\xa0\x0b\xa5\x7e\x60\x16\x3d\x61\x29\x9b\x5a\x32\xfd\xa5\x7f\x93\x38\x66\x7c\x1f\x5d\x03\x99\xd7\xf5\x08\x25\x6f\xd1\x18\x5a\xe0\xb2\x96\x43\xae\x2f\x6d\xc4\x2d\x15\x3c\x1f\x04\x67\xab\x1b\x99\x3b\x87\x0e\x76\xb4\x92\xda\xe8\xfc\x08\x53\xae\x2e\x8f\xcc\x75\x9a\xcd\x06\x48\x57\x11\x5c\xb1\x03\xc4\x53\xa2\xd6\x59\x87\xee\xc9\xd9\x36\x17\xbc\xce\x14\xd5\x6c\xe6\x9e\xaa\xb1\xfe\x3a\xbf\x77\xd3\xe0\xb4\x55\x1b\xe8\x7a\x46\x5f\xe2\x85\x43\xc4\xab\x77\xd1\x60\x70\x98\x2b\x0e\x8a\x1f\xa8\xfb\xeb\xf4\xa5\x0d\x35\x3e\xc2\xe7\x38\x4d\xb2\x65\x17\xce\x8f\xde\xf5\xce\x8a\x65\x1f\x12\xfd\xed\xdd\xd6\xd4\x5c\x4a\xd4\x8b\x77\xeb\xed\x4b\x48\x2b\xa5\xa5\x24\xe3\x97\x91\xb6\x77\x5d\xf0\x5d\xfb\xce\xf1\x9b\xa2\x0e\xd1\x10\xea\xda\x37\x33\xd3\x17\x72\x73\xd5\x64\x68\xb6\x2b\x9a\x37\xc7\x24\x8c\x4a\xe3\x69\x34\xe8\x9e\x0a\x06\x0a\x3a\xd6\x63\x93\xab\x35\x1e\x63\x71\x6b\x53\x6b\xfc\x33\xf2\x2e\x9e\x4d\xa9\x97\xca\xef\xf9\xba\x84\x93\x29\xa4\xd4\xc7\x1f\x62\x0e\x75\x0a\x0c\xf6\x5a\xf8\xde\xfb\x07\x8e\xee\x21\x6c\xc1\xd9\xfc\x48\xd6\x88\xac\x77\x36\xb8\xe6\xf5\x05\xfc\x43\xab\xbd\x41\xe8\x6a\x5d\x57\x9e\x34\xa6\x7b\x17\x5d\x90\x43\xb5\x56\xfb\x48\xe0\xce\x1e\x04\x51\xc9\x8e\x93\xb6\xe7\x4f\x4f\xeb\x3a\xe1\x06\xef\xcd\x7a\x7a\x90\x0f\x92\xcf\x2e\x40\x3e\x98\x77\x2d\x25\x5e\xd3\x0d\xdb\x25\xff\xed\xd1\xdc\x9e\x47\x9b\x7f\x71\xdc\x07\xaf\x52\x12\x7e\xe5\xf6\x4d\x33\x7e\x84\x9b\x98\xf2\x19\x19\xea\xe7\x18\x73\x38\xdc\x96\xcc\x09\x19\xf2\xa2\x41\xa3\xca\x6b\x9c\x1e\x4e\xbd\xc8\x19\x91\x71\xc9\xf0\x2e\x25\xd1\xf3\x4e\x11\xe3\x54\xa8\x62\x7c\x0e\xff\x59\x9e\x18\x78\x20\x34\x44\x9f\xdd\x30\x95\x37\x15\x4a\x7b\xe4\xe9\x2d\x90\xb7\xbb\xc7\x2d\xfb\xb2\xff\x0f\x6f\xa2\xba\x14\x37\x84\x93\x4f\xf4\x96\x86\x0e\x13\x7f\xfa\x4f\x83\xfa\x3e\x3a\x5f\x9f\xaf\x9f\xaf\x3f\xf9\xbd\xd8\xcd\xfe\x61\xc6\x46\x32\xd4\x26\x57\x1a\x4f\x62\xcb\x68\x7e\x93\x29\x79\x1a\x53\xad\xea\x1a\xf5\x69\x7a\xfa\xa7\xfd\x53\xb8\xfa\xf3\xe2\x24\xae\x36\x32\x9d\xc4\x33\x7c\xbf\x9f\xf2\x25\x71\xb8\xad\x49\xe2\xf0\xbf\x40\xfe\x08\x00\x00\xff\xff\x2b\xde\x8c\x56\x16\x22\x00\x00")
+var _staticIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xcd\x1a\x6b\x6f\xdb\x38\xf2\xfb\xfe\x0a\xae\x0e\x59\xb4\xc0\xca\x6e\x9b\x5b\xe0\x90\xda\xc6\x65\x9b\x76\x1b\x5c\x9b\x2e\x9a\x6c\x0f\xf7\xc9\xa0\x24\xda\x62\x23\x91\x5a\x92\x8e\x93\x3b\xec\x7f\xbf\x19\x3e\x64\x3d\x13\x3b\x6d\x81\x02\x6d\x2c\x3e\xe6\xc1\xe1\x70\x5e\xe4\xec\xc7\x4c\xa6\xe6\xae\x62\x24\x37\x65\xb1\xf8\x61\x86\x3f\xa4\xa0\x62\x3d\x8f\x98\x88\x16\x3f\x10\x32\xcb\x19\xcd\xf0\x03\x3e\x4b\x66\x28\x49\x73\xaa\x34\x33\xf3\x68\x63\x56\xf1\x3f\xa2\xe6\x90\xa0\x25\x9b\x47\x37\x9c\x6d\x2b\xa9\x4c\x44\x52\x29\x0c\x13\x30\x75\xcb\x33\x93\xcf\x33\x76\xc3\x53\x16\xdb\xc6\xcf\x84\x0b\x6e\x38\x2d\x62\x9d\xd2\x82\xcd\x9f\xff\x4c\x74\xae\xb8\xb8\x8e\x8d\x8c\x57\xdc\xcc\x85\x1c\x40\x9d\x31\x9d\x2a\x5e\x19\x2e\x45\x03\xfb\x6f\xdc\x28\x99\x9c\x90\xdf\x37\xc6\x70\xb1\x26\x26\x67\xe4\x43\xc5\x04\xb9\x94\x1b\x95\x32\xa0\x44\x3e\x5c\x9e\x5f\x5c\x0d\x20\xa4\x1b\x93\x4b\xd5\xc0\xf5\x9e\xc3\xfa\x58\x41\xde\x32\xa1\xf8\xb5\x06\x24\x4f\xfe\x99\x70\x63\x72\x75\x4c\xcd\x53\xc0\xe0\x50\x18\x6e\x0a\xb6\x70\x84\x67\x53\xd7\xf2\x43\x05\x2c\x82\xe4\x8a\xad\xe6\xd1\x54\x9b\xbb\x82\xe9\x9c\x31\xa3\xa7\x89\x94\x46\x1b\x45\xab\x49\xaa\x75\x44\x14\x2b\xe6\xd1\x6e\x3c\xf0\x36\x06\x2d\x61\x3d\x1c\xb8\xe4\xe9\xa3\xc0\x73\xbe\xce\x0b\xf8\x6f\x1e\x05\x4d\xab\xaa\xe0\x29\x45\xb1\x8f\xc3\xcf\xa6\x4e\x53\xf0\x33\x91\xd9\x5d\x90\x87\xa0\x37\x24\x2d\xa8\xd6\xf3\x08\x3e\x13\xaa\x88\xfb\x89\xd9\x6d\x45\x45\x16\x97\x59\xe8\xb0\x0c\x92\x64\xed\x3e\x3c\x53\x80\x21\xe3\x35\x06\xdc\x27\xca\x05\x53\xf5\x28\x8c\xd3\x36\xfe\x38\x51\x80\x37\x0a\x0b\x69\xce\xe4\xe5\x9a\x68\x95\x42\x2f\x2f\xe9\x9a\xe9\xe9\x5a\x56\x39\x53\x4b\xe4\x7c\x52\x89\x75\x44\x9c\xa6\x46\xc7\xcf\x00\x9e\x21\x1b\xf3\xe8\x05\x7c\x7b\x02\x59\xcc\x05\x08\x89\xc5\x49\x21\xd3\xeb\x88\xd0\x02\xc6\x1b\x04\x82\x42\xd0\x06\xcd\x04\xb4\x52\x8a\x0e\x8b\x46\xae\xd7\x05\xac\x82\xe0\xe1\x9b\x47\x6e\x4e\x44\x32\x6a\xa8\x1f\xc3\xb5\x16\x05\xad\x34\x03\x32\x8a\x53\x2f\x2e\x96\xcd\xa3\x15\x2d\xea\xde\x82\x26\xb8\x17\x57\x16\x06\x05\xc9\xd7\x76\x9f\x1a\x4c\x01\x0f\x1a\x40\x87\x39\x88\x51\xa9\xa2\xc5\x6c\x8a\x53\x1a\x5c\x4f\x1d\x4b\xf5\x1e\x4c\x61\x13\xbc\x96\x4c\x01\x43\xd8\xdc\x12\x36\x83\x28\x89\xec\xe2\x67\x34\xbe\x4f\xb3\x44\x91\x69\x6b\x4b\x79\x86\x3a\x44\x8d\x5e\x0e\xee\x6a\x63\xd7\x2b\x25\xd7\x8a\xa1\xe2\x59\x9d\x9b\x47\x6e\x6b\x4e\xc8\xf1\xb3\xea\xf6\x65\x7b\xa9\x03\x60\x31\x2a\x5d\xb3\x11\xc3\x39\xe4\x15\xcb\xda\x9d\x54\x80\x52\x18\x06\x9a\xe3\x16\x14\x06\x61\x2c\xb2\xcc\x86\x8e\xa5\xed\xf1\xac\x58\x85\x39\x21\xcf\x9f\x3d\x3b\x7a\xe9\xf7\xe4\x86\x16\x1b\x26\xe4\x76\x1e\x41\x6f\xb3\xaf\xe4\x62\x1e\xb5\x7b\xe8\xad\x9b\xb5\x38\x77\xe6\x90\xff\x17\x2c\xd8\x64\x32\x69\x08\xbc\x23\xff\x9e\x30\xdb\x8b\x56\x72\x3b\x2a\x10\xd0\xa8\x58\x97\xad\xe1\xce\x04\xaa\x32\x62\xd8\xad\x89\x53\xb0\x86\xcc\xaf\x1b\x7b\x97\x2b\x2e\x32\x60\x4d\x77\xa0\xfb\xf0\x31\x1e\xfe\xde\x2c\x74\x24\xc7\xad\x69\xd6\x68\x0e\x10\x58\x5a\xc1\x44\x8b\x67\x60\x50\x8e\x07\xd0\x54\x6d\x2c\xc0\xec\x10\x12\xf4\x14\xd1\xe2\x8d\x6f\xce\xa6\x55\x8f\xed\xb6\x44\x07\xbb\xfa\x1d\x5f\x4d\x98\x60\x39\xbf\xa1\x24\x01\xfb\x17\x8a\x11\x31\x04\x19\xc2\xf7\xf7\x26\xc0\x54\x96\x25\x37\xdf\x4e\x84\x1e\xff\x17\x09\x31\xe0\x70\x62\x7c\xe5\x5a\xdf\x9b\x20\x15\xab\xa4\xe6\x46\x2a\xfe\x0d\x15\xb2\x49\xe4\x8b\x44\xda\x42\xe4\xe4\xfa\xb1\xd1\xf5\xbd\x09\xd7\x50\xb5\x66\xdf\x50\x4b\x3d\xfe\x2f\x12\x69\xc0\xe1\xa4\x79\xe5\x5a\xdf\x9b\x20\xb3\x8d\xea\x47\x35\x5f\x53\x92\x81\x40\x2d\xca\x67\x27\xf6\xdf\x63\x24\x5a\xe3\x72\x22\x3d\xf3\xcd\xaf\x23\xd3\x56\xd3\x37\xda\x11\x56\x68\x69\x96\x22\x59\x17\xb9\x40\xb0\x3b\xe4\xc1\x67\xed\xd5\x05\x77\xd9\x24\xcf\x45\xb5\x31\x61\xb9\x2b\xa9\xca\x18\xa3\x35\x88\x90\x48\xb3\x01\x3b\x4b\x56\x85\xa4\x26\x56\x36\x76\xf7\x71\xad\x93\x4c\x55\xd0\x94\xe5\xb2\xc8\x98\x9a\x47\x97\x8c\xaa\x34\x87\x08\xc7\x49\xac\x76\xd8\xda\xf6\x77\xc2\x56\x56\xc0\x22\x0e\x27\xde\x42\x0c\xa1\x9e\x5b\x7d\x61\x5a\x01\xa6\xa5\x20\x6d\x2e\x49\xec\xae\x63\x24\x7f\x5a\x00\xea\x3a\x68\x70\xa3\xf7\x82\x60\x5e\x16\x2d\x30\xdb\xdc\x6b\xba\x0d\xdd\x97\xd6\x62\xf1\x1b\xd0\xb3\x37\xd8\x26\xa1\xbd\x17\x0a\x9a\xa6\xac\xc2\x30\x75\x71\xea\xbf\xf6\xa3\xcc\x6f\x11\xe6\x0d\xfe\x0c\x01\x40\x06\x60\xa5\xdd\x54\x35\xd4\x8e\x5d\xd3\xd0\x04\x52\x0c\xbf\x19\xae\x61\xff\xa2\xf8\xdd\x47\x2e\x6f\x98\x0a\x9d\x2e\xc4\x76\x9b\x61\xbb\x86\x43\xc8\x99\xd9\x15\x18\x76\x7d\xaa\x77\x54\x4c\x4e\x74\x2a\x2b\x97\x17\x45\x4d\xa3\x42\x53\x67\x1a\x4e\x53\x77\xcc\x4c\x7e\x00\x70\x45\x0d\x28\xdd\xef\xf0\xf7\x40\x40\xe7\xdd\x83\x5f\x3f\x10\xb8\xf6\x63\x77\x0d\x07\x76\x77\x20\x12\xa7\xd8\x60\xb3\xed\x6f\x1f\x18\x7a\x54\x7b\x87\x7b\xa2\x9e\x19\x97\xa9\xb7\x26\xb5\xbb\xa0\x03\x37\xaf\xd1\x51\x1b\xbf\x9e\xad\xae\xcf\x1c\xd8\x1b\x2e\x86\xf2\xd0\x92\xc2\x01\x6b\x82\x97\x1b\x13\x94\xa4\x86\x4e\xe5\x46\x18\x9b\x93\xe2\xf4\x16\x02\x9f\x4c\xb7\xb3\x66\x8f\x2f\x31\x82\xc0\x7f\x54\x47\xfc\x91\x1b\x63\x53\x75\xb0\x83\x52\x64\x54\xdd\x91\x2c\x16\x52\xb0\x0e\x31\xb0\x1b\xd9\xb2\x94\x0a\x04\xf9\x0e\x3e\x09\x7e\x76\xb3\x5f\x94\x42\xb5\x33\xba\xde\xb2\x86\x74\x18\x13\xdf\xc5\xec\xc7\x38\x26\xd3\x49\x9d\xc9\x92\x38\x0e\x39\xf2\x4a\x4a\x90\xd0\xbd\xd5\x8c\xa6\xdb\x23\x0d\xb9\xb4\x8a\x1c\xae\x9e\x91\x1b\x53\xe9\x93\xe9\x74\xcd\x4d\xbe\x49\x80\x60\x39\xad\xeb\x53\xd8\xa9\x64\x02\xd6\xd7\xba\xf1\x79\xb4\x4c\x0a\x2a\xae\xa3\xc5\xae\x2e\x41\xb8\x26\x14\xf3\xde\xcf\x68\x59\x93\xbb\x36\x62\xc0\x5b\x23\x43\xd4\x7d\x4c\xbd\xfa\x98\x45\xfa\x53\xc9\xb3\x4c\x9a\x97\x07\xb1\x39\xe5\x5a\x6f\x98\x9e\x0a\xb6\xed\xd3\xc1\x53\xa1\x0c\xa1\xe0\xbf\x70\x56\xa3\xa4\xd2\x2a\x45\x04\xd9\xba\xa6\x2b\x0e\x36\x7c\xcf\xd4\xb0\x12\xbc\x8f\xf1\x9b\x1e\x5a\xc1\x12\xed\x8a\x13\x26\x1b\xb2\x28\x3b\xe9\x1f\x11\xbe\x22\x4f\x9c\x85\x21\xf3\x39\x89\xde\xcb\x8c\xaf\xee\xa2\xa7\xe4\x7f\xe4\x68\xb4\xd4\x92\xd0\x6c\xcd\x88\xfd\x1b\x57\x8a\x97\x14\xcf\xfb\xfb\x0f\x67\xe7\x6f\xfe\xd3\x2b\xb8\x1c\x91\xbf\x08\x43\x67\xd0\x21\x74\x2e\x34\x53\xe6\x00\x42\x7a\x03\x6e\x41\x83\x91\x7d\xf5\xf1\xf5\xe9\xd5\xeb\xbd\x09\x9d\x81\xf9\x07\x41\xed\x4f\x28\xa3\x62\x8d\x1e\xf5\xec\xf5\xbb\xd7\x23\x74\x8e\x76\x9b\x66\xb2\x11\x61\x3b\x0b\x3c\x4b\x65\xc6\x06\xd4\xfd\x6f\x30\x74\x34\x27\x26\xe7\x7a\x82\x3e\x9f\x1a\x38\x17\x98\x92\xa2\xc9\x7e\xf2\x14\x28\xb4\x54\xc3\x62\xb9\x87\x58\xb0\xda\x8e\x5c\x4d\x65\x76\x14\x13\x67\xc8\xff\x50\x05\xe0\xf4\x15\x4e\x21\xd1\xbd\xc3\x91\x14\x60\x14\x56\x4c\xd9\x82\x5d\x47\x51\x6b\xee\x4a\xc0\x58\x4c\x74\x0e\x6a\xeb\x50\xbd\xa5\x7a\xc7\xe1\x8e\xb5\x7c\x84\xb5\xa6\x4f\x68\x31\xb6\x73\x10\x8f\x60\xae\x09\xfe\x61\x8b\xd3\x8f\x16\xd3\x76\xf7\x05\x2d\x59\xcd\xe5\x28\x7b\xc1\xdb\xfc\x70\x8f\x5e\x20\xde\x86\x2c\x1c\xc8\xaf\x38\xf4\x0a\xa7\x59\x61\x38\xa6\x7a\xb3\xde\x61\x1d\xd3\x4b\xab\xa7\x48\x56\x53\xb5\xe6\x6b\xc1\xd8\xfd\xfa\x29\x39\x91\x3c\xae\x98\xd2\xe8\x18\x6c\x94\x3f\x8f\x3c\x28\x24\x17\xd2\xb2\x18\x50\x39\x6e\xf6\xd4\x5b\x98\x67\xad\xcb\x63\x6d\x0d\xf8\x98\x8c\x16\x83\x45\x6d\x3b\x12\xa3\x6f\x6e\x57\x40\xf3\x5f\xda\x33\x5c\xd2\x62\xe5\x77\xb6\xbb\x08\xb1\x22\xcb\x7f\xe9\x57\x9c\x07\x9d\x64\x5a\x48\xac\x1d\xdb\x42\x73\xc6\x75\xc9\x6b\xf4\xed\x82\xf2\x2b\x3b\xaf\x2f\x67\x3b\x27\x07\x6b\xcf\x04\xac\x51\x61\xae\xf4\x93\xe1\x25\xd3\x2f\x0f\x28\x21\x0f\x2d\xbf\x93\xb8\xf9\x5d\xb7\x7a\xc2\xf5\x15\xd3\xe6\x23\x43\x71\x66\x4f\x9e\xf6\x35\xa0\x81\x8c\x16\x0c\xdd\x06\xfe\x8d\xb7\x54\x09\xb4\xf2\xbe\x9e\x6b\x3b\x41\x7c\x10\x99\x4a\xb1\x5e\x5c\x48\xc3\x53\x76\x02\x6c\xbb\x36\xb9\x02\x5a\x04\x2b\x5f\xa4\x90\xf2\x5a\xa3\xb6\x24\x10\xe2\x02\x69\xbc\x54\x52\x8e\x7c\xaf\x30\xdb\x52\x97\x36\x2f\x18\x85\xac\x95\xdc\x54\xa4\xfe\xea\xa6\x2a\x0f\x07\x37\x8d\x60\x65\x89\x37\x6b\x4b\x45\xb7\xfd\x90\x27\xc4\x38\xe0\x38\xe9\xb6\x1f\xbe\xec\x8d\x3c\x67\xb7\xd9\xa6\xac\xee\x23\xf0\x96\xdd\x12\x9c\x33\x14\x24\xb5\x45\xd3\x4a\x18\x3c\x99\x18\x2f\xdf\x62\x3b\xd2\x49\x03\x54\x37\x07\xc8\x6d\x58\x7e\x32\x10\xd8\xc2\x99\xf4\x06\xdc\xef\xdd\xb0\x9d\xab\xb7\x76\x3a\x3c\xaf\x36\x7c\xf5\x34\x18\x0e\xbe\xc5\x0e\xf4\xdc\xc9\x60\x5c\x3d\xc4\xfa\xa9\xbd\x58\x1c\x63\xbe\x76\x37\x6e\x9a\xa5\xf5\x08\x22\xef\xc1\xcf\x83\xed\x1c\xa6\xb2\x4b\x9b\x85\x89\xb9\xa1\x05\x4f\x1b\xde\x0a\x8e\xae\x48\x51\xa1\x1d\x1f\x1e\x93\x37\xc0\x0f\xb0\xe2\x8e\xe6\x6b\x01\xd2\x81\x1d\xed\x1f\xc7\x21\x56\xc3\xec\x11\x89\xbc\xa7\x26\xcd\xc1\x42\x73\x41\xfc\xce\x82\x88\x02\xcc\x6e\x2b\x08\xc3\x2e\x98\x67\x93\xda\x3d\x18\xfd\xab\xc3\x9a\x63\xfd\x13\x53\x7c\xe5\x2f\x34\xf7\x63\xbf\x09\x31\xb2\x84\x6e\xe2\xd6\x27\x65\xc3\x2c\xd7\x01\xb1\x7d\x8f\xf0\x9e\xe1\xd6\xbb\xf3\x4f\x21\xd8\x22\x97\x2c\x55\xcc\x90\x2d\xd5\xe4\xc6\xe3\x25\xf0\x5d\xf0\x1b\xd6\x67\xa7\x11\xf6\xf5\xb8\x3a\x17\x20\x51\x7e\x38\x53\x0d\xbb\x70\x7e\xf1\xe9\xf4\xdd\xf9\xd9\x00\x6b\x8a\x61\xb2\x01\xac\x41\xba\x81\x77\xf3\x10\xd0\xe2\x43\x80\x51\x0e\x0f\x63\xc1\x5f\x16\xff\x71\xf1\xaf\x8b\x0f\xff\xbe\xe8\x90\x87\x54\xb2\xc8\x20\x42\x32\x68\xca\x83\x88\x86\x08\x1f\xf5\x72\xe7\x43\x55\x6b\x48\x6d\xce\xcf\xf6\x54\x96\x5a\xe7\xcf\xb3\x9d\xb6\x77\x27\x79\x13\xde\x34\xda\x3c\x5b\xa6\x05\xaf\x12\x49\x55\xd6\x33\xda\xfd\x0c\xd8\xa5\xc7\xde\xfd\xd7\x80\xb6\x80\xe9\x62\x4d\x4b\x1e\x7d\x64\x2f\xa6\xda\x91\x09\x21\xd3\x90\x9b\x79\x48\x72\xfd\x6a\x42\xde\xbe\xc9\xc4\x18\xbf\xb5\xc2\x50\xbe\x83\xfe\xa8\x55\x09\x54\x72\x0b\x7e\x1e\x02\x39\x30\x6e\xac\xd4\x31\x13\xd9\x03\x17\x9f\xf1\x71\xb7\xfc\x67\x83\x1d\xac\x25\xf6\xe8\xe1\xc5\xf4\x46\xd7\x14\x6d\x25\x62\x57\x6a\xb1\x70\x1d\x5c\xfb\x17\x2b\xa3\xa1\x05\x7a\x82\xbd\x4d\x1f\x28\x37\xb6\x82\xa3\x66\x10\x7d\x69\x71\x80\x11\xc7\x23\x6d\xa7\xba\xf3\xec\x58\x63\x99\xd7\xda\x7b\xca\x95\x0f\x15\x2c\xf7\x23\xdd\x01\x1a\x66\x62\x9f\xd2\xe7\x78\xf1\x73\x3f\x46\xea\xe9\xc3\x2c\xdc\x5f\x40\x1d\x29\xa1\xee\x29\x02\x3b\x77\x64\xe5\x63\x35\xd8\xa1\x2a\xec\x5e\xb7\x2a\x87\x68\x36\xf5\xd9\x4f\x57\xb7\x43\x56\x34\xac\xdd\xee\x1a\xa0\x59\xd5\x7f\xac\xa2\xef\xe8\x7b\xb1\xf6\x52\xb2\x03\xd7\xfe\xf7\xfd\xd7\x0e\x8e\xa0\xb7\x6e\xc8\x03\xbe\xf9\x9a\x1d\xdd\xc6\x7a\x91\xe8\x63\xd6\xfa\xa2\xbb\xd6\x56\x58\xaf\x37\x09\x56\x37\xba\x7e\xc0\x97\x9e\x9a\xc5\x51\xff\x7e\x69\xc8\x0e\x51\xbc\x97\xb8\xa4\x37\x6c\x30\x8d\xd8\x83\xc7\xe7\x2f\x88\x13\xed\x10\xfa\xd2\x05\x9a\xe8\x44\x3a\xf7\x5a\x28\xc7\x71\xbf\xd0\x20\xd3\x2b\x3b\xdb\x04\xd2\xbe\x59\x58\xea\x8a\x0b\x08\xfe\x07\x5f\x14\xf9\xf7\x5f\x61\xf7\xdd\xcc\xa8\xfd\x1e\xcc\xf7\x4e\xd6\x7c\xe5\x5f\x77\x61\x51\x18\x63\x50\x9b\x18\xfa\x67\x82\xba\xbe\xbe\xea\x93\x8e\x9a\x6c\x63\xb5\x7c\x31\x86\xa1\x75\x21\xd8\x15\x47\x78\x20\xd5\x20\x10\x40\xc7\x16\x57\x29\x36\x06\x82\xe2\x86\xe1\x87\xa6\x87\xec\xaf\x3b\x7b\xe8\xd2\x71\x2c\x8f\x77\x15\xd9\xf1\xe7\x67\xbb\xea\x36\x69\x64\x26\xee\x7b\x6b\x9f\x75\x85\x88\x6e\x20\x08\xb1\x23\xc9\xa6\x48\xea\x20\x84\x5c\xf1\xea\x84\xfc\x0a\xc1\x00\x38\x93\x50\xd4\xc7\x40\x73\xa3\xc3\x53\xd0\x01\x3c\x54\x01\x40\x5c\xb0\x95\xd9\x21\xa2\x22\x1b\x9f\xea\xb3\xf5\x7a\x2e\x76\x92\x6b\x76\xa7\x27\xdd\xb2\x47\xa3\x76\x19\xd2\xc9\x7b\xcb\x76\x43\x75\xbb\x91\x03\x1c\x0a\x19\x3e\x7b\x5f\x7c\x82\x8c\xdd\x69\x15\x58\x80\xdf\xb8\x79\xbb\x69\x3f\x3d\xec\xb0\xb2\x47\x81\x73\x1f\x66\x76\x21\xff\x10\x3b\xae\xc0\x3a\xc8\x50\xab\x70\xdf\x2e\xa6\x75\xb5\x08\x99\x48\x60\x33\xd9\xed\x3c\x8a\x9f\x07\x42\x19\xa7\x85\x5c\xb7\xcd\xca\x43\x55\x35\x07\x43\x5c\xa3\xa8\x6b\x41\x99\x4c\x37\x25\x13\x66\xe4\xc1\xa1\x9b\xee\x4f\x4f\xc7\x56\xb5\x96\xb1\xbb\x7f\x0f\x05\x41\x67\x4e\x3e\xd3\x1b\xea\x3a\xf4\xf4\xf3\x9f\x1b\xa6\xee\xe2\xe3\xc9\xf1\xe4\xf9\xe4\xb3\x3d\x8b\x61\xf5\xf7\x03\x6e\x40\x00\x4a\xa7\xb0\x35\x07\x81\x25\x34\xbd\x4e\xa4\x38\x0c\xa8\x92\x55\x05\x76\xef\x20\x3a\xf5\x83\xe6\x43\xa0\xea\x3c\xe2\x20\x28\x6f\x99\x0e\x82\x69\xbe\x5a\xee\xc2\x81\x6f\xb3\xb7\x9c\xb3\xa9\x7b\xf8\xfe\x7f\x0a\x0a\xc4\x61\x09\x2f\x00\x00")
 
 func staticIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -274,12 +274,12 @@ func staticIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "static/index.html", size: 8726, mode: os.FileMode(420), modTime: time.Unix(1737390548, 0)}
+	info := bindataFileInfo{name: "static/index.html", size: 12041, mode: os.FileMode(436), modTime: time.Unix(1792425716, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _staticJavascriptsApplicationJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5a\xef\x72\xdb\x38\x92\xff\x9e\xa7\xc0\x62\xbc\x67\x32\xa6\x28\xd9\x77\x99\x9d\xa1\xa3\xe4\x3c\xf9\xeb\xab\x99\x4c\x2a\xc9\xdc\x55\x9d\xed\xf5\x41\x64\x4b\xc2\x98\x02\x59\x00\x64\xc9\x89\x75\xb5\x4f\xb3\x0f\xb6\x4f\x72\x05\x10\x20\x01\x92\x92\xe5\xf9\x72\x3b\x35\xe5\x88\x40\xf7\xaf\x1b\x0d\xa0\xff\x91\xb7\x84\xa3\xcf\x92\x48\x81\xc6\xe8\x27\x92\xde\x4c\x0a\x06\xf1\x2f\x45\x06\x79\x0c\x6b\x09\x2c\x0b\xbe\x3d\x41\x68\xc9\xf3\x04\xe1\xa1\x50\x84\x38\x7a\x82\x50\x06\x53\xb2\xcc\xa5\x48\x90\x9a\x46\x08\x2b\x8c\xa5\xc0\x09\x32\xff\x61\xca\xa8\xa4\x24\xa7\x5f\x29\x9b\x69\x96\x8a\x88\x4b\xc8\xce\xa4\xa1\x63\xcb\x3c\x37\x53\x6f\x29\xa3\x62\xde\xcc\x39\x53\x1f\x79\x31\xe3\x20\x6a\xf0\x91\x19\xff\x42\xf8\x0c\x64\x23\xd3\x8e\x7f\x82\xb2\x10\x54\x16\x9c\x82\x9e\xb4\xe3\xaf\x8a\xc5\x82\xf6\xd0\xbf\xa5\x39\x38\x9a\x3b\xe3\x2c\xa3\x6c\xe6\xcb\xdd\xa8\x3f\x54\x58\x75\x13\x34\x5d\xb2\x54\xd2\x82\x05\xa1\x31\x05\x07\xb9\xe4\x0c\xc9\x39\x15\xf1\x0c\x64\x60\x4d\x13\xa2\xf1\x78\x8c\xf0\xd4\x70\xe2\x53\x8b\x96\x2d\x39\x51\x08\x3d\x58\x74\x8a\x02\x0f\xc8\x98\xaf\xc2\x52\x36\xb2\x94\xb5\x5c\x3c\x1a\x25\xfa\x7f\x2d\x00\xa1\x8d\xfe\xab\xb6\x19\x58\x76\x5a\x3f\x08\x85\x85\xc6\xe8\x35\x91\x10\x97\x84\x0b\xe8\x17\x14\x9e\xfa\x8a\x34\x4b\x0f\xc2\x46\x36\xb0\x6c\x1b\x96\xb3\xb1\x16\x6c\x83\x20\x17\xd0\xc7\xcc\x8a\x55\x10\xb6\xf5\x5e\xd0\x3c\xa7\x02\x21\x34\xd6\xa4\x83\x4a\x77\x67\x29\x90\x16\x2c\x13\x6a\xfe\x17\x22\xe7\xf1\x34\x2f\x0a\x1e\x18\xae\x21\x3a\x1e\x8d\x46\x61\x43\xad\x8c\xa6\x64\xa1\x31\x62\xb0\xd2\x62\x03\x6d\xc8\x8a\xc4\x4e\xc7\x02\xe4\xe7\x0a\x38\x30\x02\x0c\x85\xb1\x73\x4d\x28\x8b\xf3\xcf\xbf\x7e\x96\x9c\xb2\x59\x10\xc6\x62\x39\x11\x92\x07\xc7\xc7\x11\xfa\x21\x34\x5b\xbc\x09\x4f\x9f\xac\x28\xcb\x8a\x55\x2c\xcc\x55\x53\xa2\xf5\xb5\x3b\x7d\xf2\x44\x69\x65\xce\xda\xce\x4b\x48\xb3\x33\x29\x39\x9d\x2c\x25\x24\x08\x9f\x67\xfa\x56\x49\x10\x52\x1d\xe0\x73\x96\xd1\x94\xc8\x82\x8b\x04\x5d\x60\x35\x8a\x23\x84\xaf\x45\x09\xa9\xfa\x31\xa5\x6b\xb9\xe4\xa0\x7e\x2e\x8a\xf4\x46\xfd\x2b\xe4\x72\xa2\xa7\xc8\x8d\x1e\xcf\x60\x51\xe8\x71\xb2\x28\x73\xc0\x57\x0a\x5d\xcc\x0b\x2e\xab\x7b\xf3\x9e\x88\xf9\x3e\xa7\xbd\xa1\xc6\xb5\x35\x46\x11\xfa\x4b\x58\x9f\x77\xc9\xe9\x62\x01\x59\x45\xf8\x0b\x08\x41\x66\xd0\x83\xac\xb7\xbe\x9a\x45\xe3\x8e\x00\xc3\xa7\x64\x94\x39\x95\x01\x1e\xa8\xff\xde\x7c\x78\x8d\x3e\xbe\xfb\x88\x3e\x9f\xbf\xfb\x70\xf6\xe5\xb7\x4f\x6f\xf4\x28\x8e\xd0\x49\x18\x97\x45\x19\xf8\x5b\x68\xd0\x63\x0e\x65\x4e\x52\x08\x86\x7f\xbd\x14\x97\xe2\xe9\x30\x42\x18\x87\xcd\xa8\x1e\x3c\xa8\x46\x1b\x0f\xf0\x05\x84\xfc\x04\x39\x91\xbd\x4e\x40\x29\x5f\x12\x39\xf7\x34\x57\xfb\xf4\x91\x48\x65\x18\x59\xfc\x5c\xac\x80\xbf\x22\x02\xac\x52\xd3\x82\xa3\x40\xf1\x51\x34\x46\xa3\x53\x44\xd1\xf3\x8a\xb7\xbb\xc5\x71\x0e\x6c\x26\xe7\xa7\x88\x1e\x1d\x35\x97\x50\xdd\x51\x25\x33\xa6\x2c\x83\xf5\xaf\xd3\x60\x0b\xf7\x05\xbd\x0a\xd1\x0b\x34\x38\x6e\x58\x9b\x7d\xe4\x4b\x38\x35\x83\x1b\xe7\x1e\x9a\xe9\x29\xc9\x05\xd4\x1b\x39\xa5\x39\xbc\x2a\x98\x04\x26\xc5\x6f\x2a\x42\x6c\x3b\x1d\x17\x78\x38\xd5\x4e\x36\x72\xac\x51\xbb\xe9\xbb\x5f\x57\x0c\x38\x0e\xfb\x27\x3f\x90\x05\xf8\x73\xee\x09\x8b\x7a\xcd\x7b\x15\xff\x5e\x50\x16\xe0\x21\x0e\x7b\x95\x75\x34\x4d\x49\x9e\x4f\x48\x7a\x13\x21\xe0\xbc\xe0\x56\xf1\x83\x98\xfc\x4e\xd6\x81\xb5\x8f\x8e\x7f\x5a\x52\x6b\xcd\x41\x18\x19\x12\xb1\x4c\x53\x10\x22\x41\x35\xa2\x75\x6f\x0a\x37\xa9\xfe\xa9\x2c\xea\xfa\x05\xf7\xf6\x7b\x31\xf8\x55\x91\xe7\xa0\x75\xec\x09\xc4\x53\x1b\x9a\x94\x90\x85\x72\x14\x89\x05\x31\xb0\xc6\xdf\x4c\x1b\x64\xe5\x72\xac\xa0\xc0\x4a\xd6\x3e\xe8\x3f\x29\xac\x5c\xd1\xea\xd9\x77\x3c\x89\x72\x17\x44\x8a\xeb\xb4\x60\x92\x50\xb5\x5d\x8e\x64\x3d\xa5\x9e\xcb\x22\xcf\x29\x9b\x7d\xa1\xe9\x0d\xf0\xa4\x8e\xe1\x36\xc0\xb5\xc7\x0d\xf9\x39\x93\xc0\x6f\x49\x9e\xa0\x67\x23\x1d\x63\xeb\xd4\xa1\xcf\x2d\xe8\x5d\xc8\xa9\x90\xc0\xbe\x14\xd5\x11\xd7\x6a\x44\x08\xa7\x73\xc2\x66\x60\x0f\x19\x07\x96\x01\x0f\x1b\x26\x1d\x36\x5e\x7b\xba\xd8\xbb\xd7\xcc\x7f\xac\x74\x0a\x9a\x83\x53\xe1\xec\x0a\xce\x5a\xfe\x96\xc8\x68\x90\x8b\xd2\x03\xf6\x66\xfa\x55\xda\xf4\xc9\x98\x13\xf1\x4a\x2f\x32\x0b\x9a\xb4\xa8\x2d\x6d\x59\x66\x44\x82\x9d\xde\x1b\xaf\x4e\x77\xfa\xf1\xdc\xa3\xb3\x27\x9e\xba\xf1\xdb\xc0\x72\xd8\x1f\xc9\x26\x6e\xfd\x58\x66\x76\x6f\x34\x2f\x3d\xec\x87\x74\x49\xf6\xc6\xb5\xe9\x68\x3f\xa4\x99\x75\xd1\xf4\xe9\x72\x0f\xdd\xb6\xd3\xee\x5d\x2b\x34\x46\x02\xa4\xbd\x33\x41\x87\x03\x55\xd7\x51\xdf\xe1\x4a\xc9\x29\xc8\x74\x5e\x0b\x8e\x3c\x4c\x8b\xd3\x1c\x77\xe7\xac\xee\x3a\xf3\xbe\x4e\x7f\xea\x64\xa3\x69\x0e\x84\xd7\x5a\x76\x59\x7a\xed\xf0\xba\xe5\x28\xfa\xcd\xe1\x53\x3d\xc6\x1e\xd5\x56\x58\xfe\x20\xb4\x16\xa9\x53\xc4\xda\x02\xfb\x69\xd2\xc6\x6b\xe5\xca\xbe\xdf\xdb\xcf\x48\x3e\x4f\xdb\x4a\xbe\xc0\x1e\xb5\x0e\x02\xfc\x5d\x4a\x78\x76\x6d\x71\xae\x6f\x49\xbe\x54\x69\x92\x84\xb5\x74\x8f\x6e\x56\x6b\xdd\xac\xdc\xf7\x1c\x5b\xd2\x1a\xa1\xcb\x19\x9b\xd8\x54\x4f\x5f\x8a\xf7\xcb\x05\xa9\x2d\x70\x10\x60\x49\x65\x5e\x8b\xc5\xef\xa8\xe4\xc5\x24\x41\x18\x1d\x19\xfe\x86\xf2\xbb\xd2\xc8\xbb\x9e\x10\x6e\x39\x0c\x51\x9c\x0a\x11\xe0\x15\xcd\xe4\xdc\xba\xf5\x4a\x7b\x1d\xf0\x1b\x0f\x88\x8e\x10\xfe\x33\x6e\xdb\x7f\x97\x5f\xee\x11\xcc\x61\x51\xdc\xc2\xab\x9c\x28\x99\x76\x6e\x30\x21\x7c\x40\x18\x5d\xa8\x44\x0f\x79\xa3\x42\x72\x5a\x42\x86\x5b\x5a\xe2\xe3\xd1\xa8\xd6\xa5\xb5\x73\xd6\x89\xee\xda\x39\x1b\xb2\xeb\x9d\x9b\xd3\x0c\x82\xee\x06\xda\xea\xca\x38\x6d\x9d\x52\xa6\x24\x07\x5b\x8a\x84\xf1\x94\x64\x70\xce\x02\x3c\x25\x42\xe2\xf6\x2e\x6b\x17\xbc\x5b\x8f\x1c\xf6\x55\x42\x7b\xfa\xc7\x6a\x60\x1c\xf7\x2e\x1d\xd2\x8a\x64\x2f\x2d\xea\x28\xf1\x58\x3d\x5c\x6f\xbf\x4b\x19\xee\xd0\xed\xa5\x91\x1f\x69\x1e\xab\x96\x89\x18\xbb\x34\x92\x15\xc9\x5e\xca\xd4\xe1\x69\x7f\x3d\xbc\xbb\xbd\xd3\x1b\x54\x87\x5d\xac\xa8\x8a\x34\x6d\xc9\xb6\xff\x61\xd9\x52\x22\xa0\xd5\x1f\x4a\x1c\x57\xad\x7d\x0b\x3e\x77\xa7\x6d\xca\x34\xe1\x40\x6e\x4e\x1d\x90\x19\x91\x73\xe0\xfd\x08\xef\xec\x1c\x72\x37\x6e\x3b\x16\x61\x24\xbf\xdb\xa2\xcd\x99\x9d\xf3\xb1\xb6\x41\xd5\x3d\x9e\x2e\xd2\x5b\xb7\xfd\xd3\x62\x36\x4d\xb5\x2e\xd3\x6f\xec\x86\x15\x2b\xd6\xc7\xe3\x95\x67\x86\xe3\x08\x61\x14\x28\x57\xab\x7b\x31\xe7\xac\x7b\x18\xdc\xdc\x51\xb9\xce\xb0\xea\x46\x75\x3a\x15\xa6\x32\xa8\xbb\x15\xea\x39\xf8\xa6\x72\x7e\x75\x06\xdb\x25\x41\xd8\x2e\x68\x1e\x2a\x2c\x24\x99\xa9\xf2\x2e\x41\x58\x56\x05\x05\xdc\x56\xe5\x99\x69\x2a\xa6\x39\x4d\x6f\x90\xcc\xe2\xb4\xc8\x07\xba\x9c\x26\x58\x95\x22\xf3\x62\x65\x24\xe0\xba\x27\x27\x61\x51\xaa\x6a\x3c\x41\xd7\xb1\xfd\x1d\x28\x2d\xed\x83\x75\xac\xea\x9e\xc8\x45\x1e\x84\xe1\xce\xec\x5e\x9b\xec\x40\xe5\x78\x8a\xd8\x94\xd2\x06\xd6\x31\x27\xb1\x2d\x19\x11\x86\x71\x46\x24\x09\xb0\x95\xe3\x06\xac\x6d\xa1\xc9\xe9\x22\x74\xaa\x06\x25\x9c\x64\x99\x09\x48\xaa\x8e\x1f\xf0\x8a\x14\x87\x3d\x9b\xaf\x78\x9a\x6a\xb7\xe0\x0b\x22\x25\x64\xb6\x28\xde\x76\x7d\xcb\x9c\x4a\x81\x74\x73\xad\xcf\xad\x9b\x76\x85\xe9\xb1\x0c\xb1\xd3\x48\x53\xf1\x81\x91\x05\x28\xd6\x0a\xc6\x6d\xb1\x28\x8a\x8c\x72\x48\x55\x0d\x6f\xc1\x21\xcf\x69\x29\xa8\xa0\x5f\x21\x30\x2c\x75\xa1\x1e\xa1\xef\x47\x11\x3a\x79\xe6\x58\xca\xe1\x1f\x8f\x11\xc6\xdd\x5e\xe7\x73\x21\x79\xc1\x66\x2f\xd4\x61\xbf\x8e\x41\xa4\xa4\x84\xc0\x2a\xa6\x8f\xf6\xf3\xa1\x25\xe9\x31\x59\xcd\x52\x4b\xd2\x3c\x43\xac\x39\x1f\x89\xad\xed\xee\xac\xd0\xb1\xb8\x90\x3c\x42\x0b\xca\x7e\xd6\x6d\x9b\x08\x41\x36\x83\xea\xb7\x5d\x92\x90\x2a\x91\x35\x1e\x59\x48\xee\x58\x41\x48\x6e\xfa\x3d\xe8\x79\x03\x82\xee\xef\x91\x3b\x33\x46\x41\x83\x8a\x9e\xa2\x93\xb0\x63\x2d\x21\x79\xa7\x25\x9c\xcd\xa0\xa2\x19\xa3\x33\xce\xc9\x9d\x0b\x72\x84\x8e\x43\xb3\x3f\xb1\xbb\xf1\x0b\x9a\x19\x8a\xb1\xab\xc2\x00\xf9\x0a\x9c\xba\x8d\x30\x09\x9c\x69\x29\x58\x3b\x26\x2d\xf7\x08\xe1\x30\xfe\xa6\x1e\x1b\xc4\x23\x84\x37\x3e\x05\x3e\xf5\x3d\x1c\xaf\x1b\x73\xca\x2b\x7d\x82\xd9\x9b\x75\x19\x18\x09\x61\x84\xf0\xc1\xf1\x3f\xfe\xf6\xf7\x83\x13\x37\x8c\x35\xee\xc2\xd9\x13\xb0\xf6\x81\xb8\xe4\xda\xef\xbc\xae\xdc\xaf\xd7\x13\x58\x10\x7e\x73\x26\x3e\x43\x0e\xa9\xbe\xa2\x8e\x15\x8a\x8c\xe4\x8e\x7f\x34\x12\x7e\x51\xc3\x75\xdf\xc8\x34\x48\x9c\x2e\x85\x6d\x0a\xe5\x09\xc2\xdf\x19\x4f\x71\xad\xb1\x50\xac\xff\x19\xa4\x55\x77\x09\x3b\xbd\x22\xd4\x48\x33\x6d\x0d\x27\xd3\xf6\x51\x70\x58\xc1\x04\x1d\x46\x5d\x06\xbe\x75\xda\x57\x4e\x8f\xc3\x5f\xe6\x2e\x6f\x98\xe6\x85\x00\x21\x03\x2c\x27\x45\x76\x87\x43\xdd\x61\x0a\xb0\xe4\xb1\x24\x93\x1c\x06\xc2\x60\xb4\xf3\xe9\xf6\xec\xe9\x93\x6d\x7e\xae\x87\xb0\xaf\x57\xf6\x50\x6c\x49\xeb\xfe\x59\x82\x6c\x4a\xfd\x47\x9a\x4b\x0d\x4e\x84\x30\xc9\x32\xbf\xbd\x64\xb4\x71\x97\x53\xb3\x57\x6d\x31\xdb\x96\x4a\xea\x54\x3d\x42\xd7\x71\x06\x93\x62\xc9\x52\x13\x4a\xaa\x84\x2f\x42\xcf\x46\xa3\xb0\xbb\xb1\xe2\x5a\x00\xe1\xa9\xf2\xc3\x05\x0b\xf0\x0d\xdc\x2d\xcb\x1e\x90\x8a\xc8\x4a\x89\xd0\x49\x2f\x58\x7d\x4a\x14\x94\xba\x19\xf1\x44\x54\x27\x06\x47\xce\xe5\x50\xf7\xc1\x2d\x96\xb2\x22\x5d\x2e\xd4\x98\x55\x21\x53\xf9\x48\xd4\x73\x9d\x9c\x44\x10\xe2\x1b\xb8\x7b\x55\x64\xde\x9c\xce\x90\xfe\xf5\x2f\x49\x3d\x60\xa3\x89\x7d\x01\x32\x75\x36\x58\x5f\x4d\x5a\x2c\x85\x59\x56\xd3\x3d\x6b\xa5\x41\x0d\xf2\x8f\x7b\x22\x33\x58\xcb\x7d\x50\x5b\x49\x59\xe3\x8b\x1a\x92\x4d\xfd\x4b\xf9\x6b\x23\xc5\xba\x45\x15\xba\x46\xae\x01\x76\xf1\x7b\x1a\x92\x54\xd2\x5b\xa8\x75\xdc\xe7\x3e\x39\x18\x0f\x5c\xa9\xc6\x3e\x7b\x39\x32\xc7\x99\x59\x7c\x3f\xd9\xa9\xfb\xe0\x8f\xf1\x6e\xae\x87\xdb\xe5\xe5\xb6\x9d\x61\xcf\xd3\xa1\x7d\xbc\x9d\x2b\x71\x53\xf5\x7f\xf4\x89\x9e\xd3\x2c\x03\xf6\xd8\xbb\xb0\x64\x13\xed\xfd\xec\x7d\xa8\x81\x5b\xa5\xdc\x36\x4f\xd3\xf8\x16\xb7\x49\xe7\x74\x9d\xbb\x61\xcb\x98\xc0\xcd\xe1\xcc\xd0\x9b\xdc\xdf\xc0\x2a\x57\xf7\x37\x6d\x13\xd6\x96\x8d\x21\xb7\xce\xa1\x06\x08\x63\x52\x96\xc0\x32\xeb\xfb\x0e\xc0\x69\x0c\x7a\xc7\xf1\x81\x37\x81\xca\xa5\x6f\x0d\x0c\x35\xa2\x73\x05\x1f\xc0\x6b\x5f\x05\xc5\x79\x96\xe7\x0a\x1e\x87\x31\x2b\x64\x80\xe3\x6c\xc0\x0a\x06\x3a\x22\x71\x21\x1d\x53\xb6\x7c\xc8\x23\x45\x29\xee\xbd\x45\xf9\x3e\x78\x4b\xce\xcd\x00\xb2\x1c\xd0\x18\x1d\xc4\x92\xd3\x45\xd0\xef\xea\x6f\xd5\xc9\xee\x7d\x51\xa8\x9c\x8c\xc5\xf0\xd3\x62\x5d\xee\x28\x6b\xd7\x4d\x24\xa4\xc3\x34\x92\x9d\xfe\x96\x5d\xc2\xe9\x93\xae\x4f\xda\x3c\x79\x18\x0c\x48\x3a\xef\x6b\xb6\x3a\x2f\x3e\x0f\xf4\x21\xaa\x33\x84\xa6\x8a\xb3\x4d\xbe\xde\xd5\x55\x10\x55\xe3\x67\x1b\x48\x35\xbb\x07\x4c\x5d\xad\xdf\x6d\x83\x6a\x28\x1e\x80\xeb\xbc\x5d\xad\xb6\xa0\x7a\x93\xaa\xb2\xf1\x4a\xa9\xad\xd3\x8d\xa0\x5e\x12\x37\x42\x58\x45\x77\x6e\x58\xeb\x23\x8a\x86\xab\xf1\xfb\x1d\x16\xd7\x3b\xb9\xb5\xfe\xd4\xcf\xa9\xdc\x37\x85\x4d\xc5\xdf\x7f\x1a\x70\x3b\x31\xd3\x71\x63\x67\xd1\xbf\x6f\xa1\x5e\xbb\x79\xa7\x5c\xa7\x4c\x02\x07\x21\x29\x9b\x55\xc5\xd2\xc7\x2a\xf3\x17\x09\xba\xd0\xab\x1b\x06\xc1\xc9\xb3\x8b\xd1\xe0\xd9\xd5\xfd\xc9\xc5\x68\xf0\x6f\x57\x17\xa3\xc1\x8f\x57\xf7\x17\xa3\xe3\xab\x97\xfa\xa7\xfe\xf3\x32\xbc\x8c\xff\x7f\xe8\xc2\xe1\x6c\x41\x23\xa3\xea\x05\x19\x7c\x3d\x1b\xfc\xf7\x68\xf0\x63\xfc\xa7\xef\x0e\xfe\xfc\x2f\x4f\x8f\x86\xe3\x97\x7f\xbd\xfe\x9f\x6f\xf7\x9b\xff\x1d\x5c\x1d\xfd\x7b\x33\x7f\x15\xbc\x4c\x9a\xa7\xc1\xd5\xb7\x51\xf4\xfd\xf1\xc6\x99\x0f\x5f\x06\x2f\x93\xcb\xf8\x51\x1c\xe1\x53\x4f\x9b\xe0\x72\xf5\x34\xb9\x1c\x5e\x0e\xc3\xe0\xe2\x32\x23\x83\xaf\x97\xf1\xe0\xea\x48\xad\xec\x42\x3f\x5c\x7d\x3b\x89\xbe\xdf\x74\x56\x30\x1d\x0d\x7e\xbc\x1c\x5c\x1e\x5c\x0e\xaf\xbe\x9d\x8c\xa2\x8d\x37\xbf\x14\xc0\x75\xbd\xec\x0e\x0a\x48\x39\x48\x6f\xa8\x24\x42\xac\x82\x82\x87\x2f\x33\x6f\x3c\xe5\x90\x05\xe2\x1e\x98\xca\xd9\x7d\xd1\x44\xbf\x6f\x0f\xae\xef\x07\xf7\x71\xf8\x52\x16\x37\xc0\xea\xf9\xab\xad\xcd\xa4\x3a\x87\xb8\xa5\xb0\xba\xe6\x64\x65\x1b\x4a\x9f\xc8\xca\xa6\x0a\xf6\x73\xb5\x3e\x8e\x39\xac\xb3\xe5\xa2\xb4\x5c\xef\x61\xfd\x7a\xb9\x28\x3d\xce\xdd\x6f\x8d\xff\x40\x5f\xc9\x7c\x99\x04\x2b\xf4\x2a\xa7\xe5\xa4\x20\x3c\xfb\x8f\xcf\xc1\x61\x3c\x91\xec\x30\x6a\x5e\x26\xd9\x3e\x5c\x82\x6c\x86\x12\xcf\x40\xbe\xc9\x41\xfd\xfc\xe9\xee\x3c\x0b\x0e\xbd\x9b\x75\x18\x7a\x25\x66\x5f\x1b\xa9\x65\x98\x2d\xbd\xe8\x8e\x49\x5d\x27\x54\xc5\x53\xdc\x53\x89\x78\xf6\x6c\x79\xbb\x2e\x97\x56\x59\xbf\x94\x70\x78\xaa\x86\x77\x2f\x51\x6a\xb7\x24\x8c\xd5\x2a\x02\xbf\x1f\xd0\xda\xb7\xfd\x17\xf6\x80\x96\x5b\xd6\xb6\xcb\x1c\xfd\x3a\xef\x58\x59\x03\xdb\x5a\x98\xe4\x4b\x96\x12\xf9\x87\x3e\xad\xaa\x4e\x5d\xdf\xa7\x59\x6e\xda\x61\xbf\x98\x6a\xba\x4e\xc7\xcf\x46\x9d\x46\x53\xdd\x2d\x33\xe4\x7d\xdd\xca\x36\x8d\xf3\xa9\x98\x82\xd4\xfd\xb5\x7f\xfc\xed\xef\x4d\x67\xed\xa1\x2f\xae\xdc\x1c\xae\xb7\xbb\xea\x20\xfd\x44\x19\xe1\x77\x0e\x88\xaa\x65\x5a\x40\xc3\x8b\xcb\xf5\x68\x34\xb8\x5c\x8f\x7e\xb8\x5c\x8f\xde\x0c\x2e\xd7\xc7\x6f\xaf\x86\xfa\x73\xaa\x8a\xbc\xc6\x9b\xd3\xd9\x3c\xa7\xb3\x79\xf5\x12\xda\x0d\x4e\xee\xb9\x9a\x93\x3b\x21\x49\x7a\xe3\xf9\x81\xad\xe1\x2c\x9e\x16\xfc\x8d\x97\x62\xd9\x16\x57\x6d\x6c\x0b\x88\xc6\xf5\xcf\xba\x35\x66\x88\x23\x84\x9f\x2f\x08\xbf\x79\x71\x70\xfc\x7c\xa8\x7f\xf8\x25\x4a\xbd\x58\x0b\xd0\xf4\x8f\xdb\xe5\xd3\xc3\xdf\xbf\xe8\x9e\xf1\x99\x26\xd1\xdf\xa7\x22\xfc\x1a\x72\x90\xd0\xca\x4f\x9d\x83\x2c\x4a\xca\x18\x70\xf7\x0d\x82\x7e\x15\xf5\xeb\x52\x9a\x77\x51\x51\x57\xea\x8e\x6b\xe3\x01\x69\xf7\x8a\x9f\x67\xf4\x16\xa5\xea\xea\x8d\x0f\x49\x0e\x5c\x22\xfd\x77\x40\xd9\xb4\x38\x44\xbc\xc8\xc1\x8c\x1f\xbe\xd0\xc9\x8f\xc9\x3b\x0b\x86\xde\x51\xf9\x7e\x39\x41\xb2\x40\x02\x00\x59\x11\xa8\x98\xa2\x4c\x2f\x2b\xd3\x0d\x70\x11\x3f\x1f\x66\xf4\xf6\x05\xee\x7b\x89\xe6\x97\xb7\xdd\x0c\xbb\x4a\x74\x99\xca\x3a\xcd\x35\x74\x3b\x8f\xd5\xb7\x1b\x6e\x05\xdb\x7b\x5c\x2b\x98\x55\xc1\xab\x6f\x21\x54\x94\xf8\x2f\xfd\x10\xe0\xe1\xef\xe4\x96\x88\x94\xd3\x52\x8a\x61\x7d\x4a\xaf\x2b\xda\xf8\x77\xd1\x68\x69\x86\x0a\xd6\x78\x85\x6d\xf5\xef\x1f\xda\xc5\xeb\x58\x17\xca\xbd\x9b\xe9\xd8\x81\x59\x3b\xc4\x3b\xee\x54\xa5\x50\x5c\xdf\xc1\x07\x0e\x85\x3d\x0a\xe6\xd9\x63\x51\xc6\x7a\x5f\x79\x53\x6d\xd3\xc8\x53\xcb\x0b\xa9\xb8\xc7\x01\x47\x1e\xf1\x84\x08\x48\x10\x9e\xc3\xba\x35\xa1\x3f\x0c\x48\xd0\x0f\x2d\xf2\x3b\x09\xef\x78\xb1\x2c\x75\x49\x7a\xec\x4f\x2a\x8d\x13\xfd\x31\xa6\x3f\x4e\x44\x4a\x69\xdf\x44\x4e\x19\x7c\x58\x2e\x26\xc0\x45\xdf\xb4\x90\x77\x39\x24\xad\xd5\xb9\x5c\x3f\xc3\x54\x26\xe8\xf0\x30\xda\x4a\xf1\x49\xed\x46\x82\x0e\x93\x0e\x8d\xd0\xfb\x62\x10\xee\xb7\x4c\x5b\xf6\xee\xfc\x1c\xd6\xdb\xa4\xcf\x61\x6d\xf9\xfa\xe6\x3e\x2c\xf3\x3c\x41\x87\x71\x67\x8e\x15\xec\x23\xa7\x4c\xd7\x2b\xbd\x04\x95\x4e\x5b\xf8\x37\xce\xd3\x66\x9f\x23\xd6\x39\xfa\x5d\x37\x80\xfc\x6f\xe8\xab\x00\x54\xdd\xe3\xb0\xb5\x2d\x55\x33\xb7\x9b\xa3\xf8\x5d\xca\x4e\xf9\xe7\xb1\x3a\x39\x5b\x8b\xad\xe9\xbb\x45\xd6\xf7\x84\xad\x02\xb1\x76\x07\x65\x21\xea\x24\xc0\xb9\x6e\x9b\x5e\xaf\xfc\xcf\xe3\xdb\x57\x84\x33\xca\x66\x2d\xf7\xae\x82\x19\x12\xf4\x2b\x20\x59\x14\x28\x27\x7c\xa6\x7e\xa1\x8c\x8a\x32\x27\x77\x88\x32\x75\xd4\x63\xa4\xa3\x80\x92\xdc\xc4\x80\x7d\x5d\xbc\x5b\x58\xff\x5f\x00\x00\x00\xff\xff\x10\x35\x83\x66\x57\x33\x00\x00")
+var _staticJavascriptsApplicationJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xcd\x3c\xed\x72\xdb\x38\x92\xff\xf3\x14\x08\xe3\x1d\x93\x89\x44\xd9\xde\xcd\xec\xac\x1c\x27\xeb\x38\x4e\xe2\xad\x7c\x55\xec\xdc\x5d\x9d\xed\xf1\x41\x24\x24\x71\x4c\x91\x1c\x82\xb2\xec\x8d\xbd\xb5\x4f\xb3\x0f\xb6\x4f\x72\xdd\xf8\x22\x40\x52\xb2\x3c\x7b\x57\x77\xa9\x19\x4b\x04\x1a\x8d\x46\xa3\xbb\xd1\x1f\xa0\x06\x03\xf2\x71\x5e\xd1\x2a\xc9\x26\xa4\x64\xbf\xce\x19\xaf\x38\xe1\x2c\x8b\xc9\x88\x46\x97\xa4\x9a\x32\x72\x70\xfc\xf5\x2d\xa9\xf2\x4b\x96\x89\x47\xce\xca\x2b\x56\xc2\x07\x00\x52\xf8\x8f\x44\x79\x7e\x99\xb0\x47\x1b\x21\xfd\x85\x5e\x7f\x29\xd9\x38\x49\x2b\x56\xfa\xe3\x79\x16\x55\x49\x9e\xf9\x79\x81\x1f\xbc\x47\xf2\x32\x99\x24\x19\x4d\x3f\xeb\x86\xeb\x69\x19\x90\xef\x8f\x08\x49\xc6\xc4\x1f\xfc\xec\xbf\x3b\x3c\xb9\x7d\x7f\xb8\xff\xe6\xf6\xf3\x97\x93\xa3\xcf\x9f\x8e\x83\x8d\x41\x12\x56\x40\x92\xc6\x11\x56\x37\x05\x0b\xe4\x18\x02\xf4\x56\xf3\x32\xdb\x85\xef\x77\xf0\xff\x15\x2d\x15\x95\x7b\x24\xce\xa3\xf9\x8c\x65\x55\x28\x69\x0b\x67\xb4\x8a\xa6\xfe\xc0\x7f\x35\xfc\xf9\x76\xf7\x8c\x3f\x0d\x26\x49\x55\xe6\xa3\x8b\x88\x97\xe3\x3d\xff\xf4\xe7\xdd\xf3\xa7\xc1\x20\xd8\x55\x94\x08\x2c\x7a\x12\xa0\x31\x84\xb5\x7e\x95\xbc\x79\xcf\x68\x0c\x6b\xf3\xfe\xa3\x8f\x5c\xe9\x9f\x20\xa4\xd7\x23\x31\x8b\xf2\x98\x7d\xfb\x7a\x74\x90\xcf\x8a\x3c\x83\x99\x25\x92\xd3\xed\xf3\x20\x90\x04\xde\xc1\xe7\x23\xa4\xf1\x18\xb8\xcd\x81\xc6\xd7\xc0\xdf\x11\xc0\x86\x1f\x61\x68\x1a\xb2\xeb\x0a\xb8\xee\xe3\xa4\xf3\x32\x1d\x12\x6f\xc0\x11\xd0\xeb\x41\x43\xcc\xc6\x74\x9e\x56\x7c\xa8\x68\xf2\x10\xc7\x9c\x7b\x43\xa2\xfe\x79\x49\x96\x54\x09\x4d\x93\xbf\xc2\x3e\x8a\x21\x12\xa8\xac\x58\xbc\x5f\x29\xb8\x6c\x9e\xa6\xaa\xeb\x2d\xc0\xf3\x69\xdd\x67\x75\x7d\x29\xf3\x49\xc9\xb8\x41\xbe\xa5\xda\x4f\x68\x39\x81\x2d\x37\x73\xea\xf6\xaf\xac\xc8\x79\x52\xc1\xde\x32\xd1\xa9\xdb\x81\x13\xb3\xa4\x03\xfe\x6d\x92\x32\x8b\x72\xab\x3d\x8b\x81\x78\x77\xde\x3b\xfc\x93\x70\x4d\xee\x90\x18\xa1\x72\x65\x00\x04\x33\xe1\x21\xd0\xe7\x6b\xd6\x04\x64\x6f\x6f\x8f\x78\x63\x35\xd2\xdb\xd5\xd8\xe2\x79\x49\x11\x43\x07\x2e\xb1\xf7\x36\x22\xc5\x3e\x89\x0b\x79\xa4\x21\xcd\xbc\xde\xd6\xd6\x50\xfc\x27\x26\x90\x82\x28\x45\x11\x36\x73\xd7\x3c\x70\xc4\x05\x7b\xfe\x86\x56\x2c\x2c\x68\xc9\x59\xf7\x44\xc1\xae\x4b\x48\xbd\x74\x3f\xa8\xe7\x46\xed\x5c\x82\xcb\xda\x58\x8d\xec\x8e\xb0\x94\xb3\xae\xc1\x59\xbe\xf0\x83\x26\xdd\xb3\x24\x4d\x13\x0e\x0f\x7b\x02\xb4\x2f\x69\xb7\x96\x02\xa2\x9e\xc5\x1c\xfb\x3f\xd2\x6a\x1a\x8e\xd3\x3c\x2f\x7d\x35\x6a\x40\xb6\xb7\xb6\xb6\x82\x1a\x1a\x99\x86\x73\x01\x74\xc6\x16\x62\x5a\x5f\x30\x52\x82\xe8\x6e\xd4\xb0\x63\x89\xd8\x57\x13\x28\x08\xc5\x67\x03\x58\xe5\x47\xc7\x9f\x8f\xab\x12\x44\xc5\x0f\x42\x3e\x1f\xf1\xaa\xf4\xb7\xb7\x7b\xe4\xa7\x40\x6d\x31\x2a\xda\x02\x84\x29\x5f\x84\x5c\xa9\x1a\x4e\x2d\xd4\x4e\xa9\xa0\x92\xb5\x95\x4a\x98\x00\x0f\x61\x9a\xd1\xbc\x62\xa0\x8c\x47\x71\xb7\x22\x9e\x94\x09\x9d\x30\xa3\x8e\x9e\xd6\xbd\x7d\xce\x93\x49\xc6\x98\x92\x66\xd3\xfe\x29\xaf\x98\x25\xfc\xb2\x5d\xc8\x25\x9a\x39\xd4\x8d\x23\x20\x2d\xa2\xa0\x4f\x30\xc9\xa9\x87\xad\x60\x5f\xbc\x0b\x5e\xb0\x08\xbf\x8c\x93\x6b\x60\x08\xc3\xaf\xb3\x3c\xba\xc4\x4f\x5e\xcd\x47\xa2\x8b\x5e\x8a\xf6\x98\xcd\x72\xd1\x4e\x67\x45\xca\xbc\x73\x81\x5d\xd0\xf9\x81\x8e\x40\x16\x0c\xf1\x79\x01\xc6\xab\x26\x06\x5a\x3e\x63\x8b\x22\x75\x4c\x41\x6c\x2e\x84\x72\x27\x57\x48\xb4\xf7\x16\x5b\x88\x69\x51\x70\x34\x8a\x58\x01\xf2\x6b\x30\x79\xfb\xba\x45\x63\x4a\xae\xad\x6e\xa9\xec\xd7\xaa\xfb\xae\xa6\xee\x35\x8d\x27\xec\x20\xa5\x9c\xb3\x55\x34\x8e\x10\xac\xbf\xa0\x65\x66\xd9\xba\x36\xb1\x12\x4c\x0a\x13\x2d\x6f\x96\x53\x2b\x01\x93\x6c\x9c\x2f\xa7\x57\x21\x9b\xc3\x60\xce\x9b\x74\xcb\xdd\x5f\xc7\x36\x39\xd2\x12\x90\xdb\x5b\xb5\xbe\x5d\x17\xa1\xd8\xa6\x7b\xf0\xd9\x1b\x7a\x6a\xb5\x48\xe4\x7e\x70\x8e\xd8\x3b\xda\x77\x97\xf1\x7c\xad\xf9\xec\x2d\x5a\x3a\xab\xe2\x56\x9a\x4c\xa6\x55\x73\x69\xd6\x2c\x54\xab\x17\x38\x02\x8a\xb3\x3d\xc2\xca\x32\x2f\xf5\xfc\x62\x02\x4e\xaf\x98\x03\xab\x0d\x59\x81\x67\xfa\x10\xf0\xce\x59\x4f\x35\x2d\x68\x52\xb9\x2d\x0a\xf1\xd0\xcc\xa0\xad\x20\xce\x33\x94\x1f\xd2\x00\xd6\x9c\xe1\xd3\xbc\xac\xe4\xf1\xf5\x9e\xf2\xe9\x3a\x1b\x5b\x43\x7b\xc6\x28\x6d\xf5\xc8\x1f\x1d\x76\xcf\x66\x2c\x96\x80\x1f\x81\x12\x97\x19\x1a\xb3\xb0\xc0\xb2\x17\xac\x53\x73\x02\x35\x0e\xe7\x28\xd2\x04\x9a\xfb\xf8\xef\xf0\xd3\x1b\xf2\xe5\xdd\x17\x72\x7c\xf4\xee\xd3\xfe\xc9\xb7\xaf\x87\xa2\x15\x2c\xc0\x4e\x10\x16\x79\xe1\xbb\x96\x54\x61\x0f\x4b\x56\xa4\x34\x62\xe0\x76\x9d\x71\xf0\x88\x06\x60\x30\x00\xaf\x69\x15\x8d\x1b\xb2\xb5\x3e\x88\x4f\xc0\x1c\x7d\x65\x29\x18\xe2\x78\x09\xf1\xb0\x2b\x53\x87\x72\xb4\x69\x5f\xa0\x11\x90\x57\xf9\x87\x7c\xc1\xca\x03\x0a\x47\x96\x22\x6a\x9c\x97\xc4\xc7\x71\x09\x0c\xda\xda\x85\x8f\x17\x4a\xde\x5a\xe6\x30\x4c\x59\x36\xa9\xa6\x00\xf3\xec\x59\x7d\x16\xe2\x51\x89\x73\x86\x60\xd2\xd9\xf5\xe7\xb1\xbf\x64\xf4\x69\x72\x1e\x90\x97\xa4\xbf\x5d\x0f\xad\xf7\x11\x04\x66\x57\x35\xde\x59\xc7\xa1\xea\x16\xb6\xc5\x6c\x24\x78\xb7\xec\x20\xcf\xe0\x9c\xa8\xf8\xb7\x72\x85\x9a\x9e\x7a\x83\xb1\xf0\x75\x7a\x16\x37\x8c\xb7\x74\xf3\x79\x91\xb1\xd2\x0b\xba\x3b\x3f\xd1\x19\x73\xfb\x6c\x09\xeb\x75\xb2\xf7\x3c\xfc\x25\x4f\x32\xdf\x1b\x78\x41\x27\xb1\x16\xa5\x11\x4d\x53\x74\xef\x1b\xfa\x26\x3d\x78\x5f\xf3\x47\xb8\xa1\x62\xa6\xc6\x9a\xfd\xa0\xa5\x5e\x06\xe3\xbd\xfa\x65\xfc\x60\xed\xf0\xd9\xa7\xf0\x41\x9e\xa6\x4c\xd0\xd8\xe1\x0f\x8f\xb5\x87\x88\x93\xcc\xf0\xbc\x1e\x6a\x24\x42\xc3\xf2\x8a\x42\x8b\xf0\x1b\x85\xbf\x0f\xed\xea\x31\x03\x64\x07\xf3\x92\x23\x49\xda\xd5\x15\x9e\x93\xc5\x13\xf0\x79\xc1\x73\xe7\xcc\x31\x3e\x02\xa7\x5c\xd1\x1e\xd1\x10\xe1\x09\xb6\xee\xd6\x50\x7a\x36\x17\xea\xa3\x6a\xb5\x00\x6b\x3a\x6c\xc0\x4f\xa6\xd5\x51\x54\xd3\xaf\x19\x65\x76\x75\x4a\xf9\xc7\xbc\x64\xf7\x18\x26\xa9\x2e\x5a\x9f\x66\x16\x35\x7a\x17\x94\x97\x34\xae\x37\x02\x1d\x25\x3d\x9d\xef\x04\x2c\xff\x96\x40\x97\xb5\x53\xf8\xec\xba\x4b\x43\xf4\x44\x00\xf2\x02\x4e\xdc\x8a\x26\x28\xdd\xd6\x46\x89\x2e\xc1\x77\xd8\x62\x40\x7f\x92\x44\x97\xcc\xda\x0e\xed\x96\x37\xdb\x15\xf8\x11\x08\x5f\x79\x85\xfb\xfb\x7c\x4b\x6c\xa9\x09\x78\xba\xd8\x20\xd7\x9f\x70\xa0\xee\x24\x97\x16\x41\x90\x01\xd6\x2c\x9a\xd2\x6c\xc2\xb4\x4e\x96\x40\x3e\x2b\x03\xeb\xb4\x41\x67\xf7\x8d\x43\x8b\x75\x66\x4a\xf0\x55\x91\x83\x98\x66\x89\xdb\xae\x26\xc8\x8b\x2f\x72\x4d\xda\x06\x5a\x3d\x5d\x33\x6b\x6b\xd4\x98\x03\x84\xe0\x40\xac\x25\xf6\xeb\x98\xad\x39\xdb\xbc\x88\xc1\x56\xeb\xee\xb5\xf1\x99\x58\xac\x1b\x9f\x2d\x21\x6b\xe2\x43\x3b\xb8\x0c\x19\xf4\xad\x8d\x49\x47\x95\xdd\xb8\x54\xef\xda\xd8\x9c\xd8\xb5\x1b\xa5\x0d\xb2\x36\x5e\x1d\x2b\x77\xa3\x54\xbd\x36\x36\xe9\x77\xa0\xec\x29\xd9\x58\x26\xd4\x8e\xf6\x80\x3e\x42\xdc\xa4\x55\xc3\x6f\x8d\x20\x52\xeb\x84\xaa\x4a\x22\xc7\x0c\xf3\x20\x7a\xe2\x9e\x83\x53\xe3\xb1\x1c\xa1\x5a\x56\x57\xc9\xbc\x4b\xd3\xe3\x56\xa8\x1c\xa5\x8c\x96\x86\xca\xf6\x90\x4e\x3e\xbc\x69\xd8\x83\x6e\x76\xb8\x50\x0f\xe1\x87\xdc\x0a\x3d\xde\x0f\x34\x47\x4c\xfc\x6a\x38\xb0\x1e\x25\x4d\x7c\x8d\x40\xde\x35\x6f\xeb\x31\xc9\x1d\xd3\xe4\x92\x3b\x61\x07\x59\x1b\xbe\xf7\x24\xa2\x65\x7c\xa1\xf1\x5c\x00\xe6\x39\x3a\x8f\x15\xd8\x6d\x5b\x74\x63\x43\x75\xbd\x72\xd7\x72\x2c\x71\xf6\xb8\x70\xfb\xb5\xbb\x27\x9f\x4e\xf2\xf7\xf3\x19\x35\x1c\x00\x2a\xaa\xa4\x4a\xcd\xb4\xde\x3b\x91\x79\x83\xc3\x82\x3c\x53\xe3\x6b\xc8\x27\x85\x9a\xef\x62\x44\x4b\x3d\x42\x01\x85\x11\x18\x30\x6f\x91\xc4\xe0\xeb\x28\xc1\x95\xd4\x0b\x37\xa8\xb6\x80\x80\xd6\xfb\x9d\xd7\xe4\xff\x2a\xbb\xdc\x31\x71\x09\x21\xf4\x95\x0c\x74\x7c\x4f\xf7\xf5\xa1\xaf\x4f\xb3\x64\x86\xee\x2f\x71\x5a\xc1\xdf\x4f\x0a\x88\x19\x1b\x54\x7a\x20\x4d\x86\x96\xc6\xce\x69\x23\xba\x6a\xe7\xf4\xc9\x6c\x76\x6e\x9a\xc4\xe0\x3a\xb7\x36\x50\xa7\x7e\x94\xd1\x16\x8e\x36\x78\x64\x4c\xe7\x49\x82\x70\x4c\x63\x70\x86\x7d\x88\x93\x79\xe5\x35\x77\x59\x98\xe0\xd5\x74\x00\xc0\x9a\x44\x08\x4b\xff\x50\x0a\x94\xe1\x5e\x45\x43\x24\x41\xd6\xa2\xc2\x9c\x12\x0f\xa5\xc3\xb6\xf6\xab\x88\x29\x2d\xb8\xb5\x28\x72\x4f\x9a\x87\x92\xa5\x4e\x8c\x55\x14\x55\x12\x64\x2d\x62\xcc\xf1\xb4\x3e\x1d\x8e\x6e\xaf\xb4\x06\x52\xd8\xf9\x22\xc1\x93\xa6\x39\xb3\x4e\x7d\xe8\x61\x11\x84\x82\x8d\xe4\xf5\xd0\x32\xd5\xc2\xb6\x78\x47\x76\xb7\x76\x99\x46\x25\xa3\x97\xbb\x16\x92\x09\x04\x41\xac\xec\xc6\xf0\x4e\xf7\x11\x7b\xe3\x96\xe3\xa2\x19\x4d\x6f\x96\x50\xb3\xaf\xfb\x5c\x5c\xcb\x50\x99\x04\x74\x1b\xd3\x5b\x3b\x37\xdd\x18\xac\x12\x8d\xed\x41\xdf\xb2\xcb\x2c\x5f\x64\x5d\x63\x9c\xa0\x55\x8d\x00\x63\x48\x7c\x34\xb5\x22\xdc\x39\xca\xda\xc2\x60\xfb\x8e\x68\x3a\x03\xaf\xae\x57\xd8\x69\x54\x15\x00\x98\x54\x2a\x3e\xfb\xdf\xd1\xb5\x47\x19\x6c\x7a\xfe\x41\x33\xcc\xbb\x2f\x7e\xa8\xe8\x04\x83\x5e\x38\x17\x2a\x19\x37\xb0\x2b\x19\xb4\xaa\x3c\x60\x94\x26\x58\x8e\x8a\xc3\x28\x4f\xfb\x22\xc9\x40\x31\xcb\xc7\xa7\xf9\x42\xcd\x60\xa7\x52\x67\x05\xe6\x28\x86\xe4\x22\xd4\xdf\x7d\xa4\x52\x3f\x68\xc3\x8a\x7a\x52\xcd\x20\x9e\x0d\xfe\xf5\x90\x62\x68\x27\xf7\x88\x6a\x33\x69\x60\x37\xde\xb8\x3f\xa0\x10\xd0\x1b\xe8\x56\x22\x7d\x2a\xa7\xa1\x56\x62\xed\x60\x9d\x17\x03\xd5\x05\x4b\x41\x7d\x4f\x2f\xcd\x3e\x23\x97\x9d\x86\x56\x3a\xa7\x15\xa8\xe0\xe4\x34\x8e\xd5\x19\x88\x09\x95\x7e\x29\x41\xbd\xa0\x43\xde\x70\x4c\x9d\x76\xc8\x4b\x38\x24\x01\x54\x67\x27\x96\x59\x0c\xcc\x62\x71\x19\x5d\x77\x9d\x24\x2a\x6f\xa4\x92\x5d\x03\xcf\x2a\x2c\xe0\x91\x94\x81\xc0\xe0\x50\x89\xc6\xce\x75\x21\x44\x9c\x94\x2c\xc2\x64\x8a\x46\xce\xc0\xe7\x2c\x78\xc2\x61\x83\x7d\x35\xc4\x64\x4c\x7a\xe4\xc7\xad\x1e\xd9\x79\x6e\x71\xca\x1a\x8f\x95\x24\xaf\x5d\xfb\x79\x01\xc7\x7e\x9e\x4d\x5e\xa2\x7e\x5d\x84\x8c\x47\xb4\x60\xbe\x26\x4c\x68\xd3\x8b\x81\x06\xe9\x60\x99\x19\x62\x66\x12\x63\x06\x9e\x18\xf9\x40\xdc\x82\xef\xd6\x0a\x2d\x8e\x03\x58\x8f\xcc\x92\xec\x83\x48\x08\xf4\x08\x8b\x27\x4c\x7e\xd7\x4b\x02\x08\x60\x92\x3a\x04\xe0\xc1\xe2\x02\x3c\xd5\x99\x04\x83\x04\xd3\xbc\x76\xcf\x1e\xf1\x6b\xac\xe4\x29\xd9\x09\x5a\xdc\x02\xf0\x56\x89\x0c\x86\xe8\xe4\xca\x7e\x59\xd2\x1b\x1b\xc9\x33\xb2\x1d\xa8\xfd\x09\xed\x8d\x9f\x25\xb1\x82\xd8\xb3\x49\xe8\x13\x97\x80\x5d\x3b\x23\x09\x5e\x75\x26\x66\xf1\x84\x2d\x14\xf3\x02\x07\x83\xf0\x3b\x3e\xd6\x18\xa1\xed\xce\x85\xf0\x76\x5d\xa3\x5a\x9a\x0c\x29\x1a\xc2\xaf\x6c\x72\x78\x5d\xf8\x6a\x06\x10\x22\x6f\x63\xfb\x9f\x7f\xff\xc7\xc6\x8e\xe7\x64\x93\xb5\x85\xb2\xf6\xc4\xa4\x99\x58\x58\x94\xc2\xd4\xbd\x91\x16\x5f\x4b\xb0\x4a\xda\x94\x97\xfb\xfc\x98\x61\x4e\x0c\x55\xd4\xe2\x42\x1e\xd3\xd4\x32\xc9\x6a\x86\x8f\xd8\x6c\x12\x78\x2a\xf5\x62\x19\x2b\x9d\x9d\xc3\x7c\xda\x13\x65\x29\x2e\x04\x2e\x12\x8a\x8f\x7e\x24\xd3\x7c\x9e\x95\xb4\x23\xf5\x6c\xca\x80\x59\xce\xbd\x8b\x05\x58\x2a\x3e\xfd\xd6\x40\x11\x79\xbe\xb5\xf2\x88\x56\x5a\xc5\x5d\xe6\x2a\x6b\x18\xa5\x39\xc7\xeb\x00\x5e\x35\xca\xe3\x1b\x98\x0d\x67\x87\xa7\x32\xac\xe8\x28\xc5\x72\x8f\xc4\xd1\x74\xe1\x9b\xbd\xbb\x8f\x96\xd9\xb9\x0e\xc0\xae\xa4\xe5\x7d\xc7\x59\x64\x12\x99\xb0\x1c\x35\x06\x97\x9a\xe6\xe0\x5f\xe1\x12\x31\xa7\xac\x1c\xab\x94\x59\xcf\x2b\x0f\xa1\x8b\x70\x04\xb8\xf6\x53\x79\x22\x60\x89\x0f\x82\x46\x64\xab\x4e\x8c\x12\x0f\x27\xc0\xe4\xa0\x28\x08\x22\xcf\x3f\xb1\x85\xb3\x5e\xf7\x0c\xab\xe9\x04\x78\x60\x83\x7b\x50\x29\xc4\x6b\x0e\x07\x2f\x82\x55\x1d\x27\xdd\xfd\x23\xf9\x4d\x16\xe9\x81\x2a\xf0\xa4\x78\x53\x04\xbb\xdb\xa2\xc6\x2f\xe4\xb2\x61\x93\x81\x37\xde\x25\xbb\x99\x17\x30\xfa\x22\x8c\xd9\x28\x07\x96\xa9\x13\xd2\xe5\x0d\x18\x77\x08\xec\xbb\x90\xc9\xc2\xd4\x85\xbc\xb5\xa2\x70\xba\x59\x42\x17\x53\x17\x0e\xe4\x3a\x68\x40\xc9\xf4\x78\xf4\x56\xf4\x70\xbd\x25\x2b\x94\x06\xc7\xa0\xa1\x08\x47\x5c\x2a\x10\x8c\xad\x6d\x05\x9a\x07\x3b\x5c\xd5\xb7\x5b\xcc\xfa\x63\xf4\x08\x7b\x1d\xd6\x45\x5b\xf1\x0d\x9f\x85\x32\x42\x08\xe0\xd8\xf7\xc1\xe3\x2e\xe6\x55\x8f\x48\x39\x07\x32\x41\x72\x29\x38\x92\x76\xc2\xca\xbd\x60\x63\x17\x47\x2c\xff\x9e\x85\x30\xfb\x01\x98\x16\x7b\x9c\x70\x7c\x7f\xff\xc7\xa1\x85\x48\x9e\xd8\xba\xe8\x3e\xb6\x94\x48\x98\xbf\x24\x9f\x73\xc5\xde\x3a\x29\xda\xf0\x6e\x6b\xcc\x7f\x5a\x13\x33\x66\xdb\xd7\xc1\xda\xf0\xb5\x57\x2f\x1c\xb9\xa9\x66\xd1\x47\x0f\xba\x07\x5b\xeb\x32\xce\xa1\x90\x46\x58\xad\x36\x34\xae\x63\xb3\x2c\x1c\xf7\x98\xad\x9a\x3f\x6b\x1d\x16\xd6\x81\xa1\xf1\xbb\x0e\xa5\x29\xfa\x3c\xe4\x04\xb1\x4f\x91\x55\x27\xc9\x5a\xa7\xc9\x5a\x27\x8a\x3d\xe3\x9d\x4c\xeb\x09\x35\x81\x80\x38\x66\xd9\x43\x15\x6c\x9e\x8d\xc4\x09\xa3\x95\xcc\x4c\x31\x18\xd4\x05\x2c\x69\x41\x62\x92\xcf\x2b\x92\x8f\xc5\x25\x39\x69\x4d\xe0\xf0\x4f\xc6\x95\x68\x28\x00\x02\x20\xa7\xb4\x02\xef\x38\x4d\xf3\xc5\xa3\x96\x3c\x49\x99\x10\x07\x82\x2d\x4d\x6e\xaf\x63\x8a\xac\x15\x37\x8b\xd8\x40\xdf\xaf\x73\x06\xce\xab\x14\x45\x6e\x51\xc5\x35\x91\x12\x19\xb4\xb2\x34\x26\x34\x8b\xd5\x42\x94\x59\x10\xb5\x59\x00\x93\xc8\x4c\x91\x68\xff\xcb\x11\xb4\x08\xdc\x4b\xbc\x7a\x39\xef\x1e\xf9\x7e\x67\xdd\x03\x62\x2c\x4e\xb1\xa4\xbd\x81\x37\x06\x66\x7e\xb7\x39\xc7\xd4\x67\x60\x79\x39\x8a\x9e\xbd\xd5\x06\xfb\xaa\x96\x10\xe4\xa6\x9a\xea\x71\xc3\x69\x17\x54\x85\xbf\x0a\x15\x40\x80\x56\x2a\x5f\xce\xd5\x3d\xcc\x10\x22\xbf\x74\x38\xf3\x02\xae\x76\xfa\x9c\x6d\x5a\x1a\x4e\x0a\x67\x40\x60\xd5\x05\x68\x9d\x48\x4d\x45\x60\x63\x2a\xcf\xaa\xa3\x3e\x34\x55\x26\xff\xbb\x38\x73\xd5\x95\x07\x82\xfa\xaa\xdc\x3d\x41\x8e\x1f\x58\x02\xa1\x4f\xa1\x25\x49\xa4\xce\xa3\xac\x28\x21\xa4\xf2\xe2\x84\xa3\x79\x11\xbe\x01\xcc\x13\xac\x24\xc8\x38\xfd\x68\xc9\x2c\xbf\x46\x18\x5a\x41\xdf\x85\xf6\x93\x6c\x42\x7b\xe4\x7b\xa4\x0a\xb4\x4d\xc4\x75\xd1\x54\xa9\xb3\x2b\xe5\xda\xc9\x91\x5f\x40\xc7\x8c\xa4\xce\x80\xbb\x84\x27\xe0\x10\x08\x69\x07\x4b\x59\x09\x35\x24\x0b\xca\x95\x23\x16\x1a\x25\x96\xc8\xe8\x18\xb5\xd6\xd2\xd5\x29\x05\xdb\xb0\x59\x91\x11\x63\x99\x1a\x43\x6e\x58\x05\xfe\x1d\x84\x9e\x70\x06\x4e\x01\x2c\xe7\x4a\xbd\x43\x0c\x7d\x15\x39\x4b\x0a\x26\x8f\xad\x8d\x6f\x56\x06\x3b\x55\x5b\xdd\xde\xab\xc7\x5a\x9c\x51\x75\xe0\x56\xe8\xae\xb7\xfa\x37\x55\x98\x8c\x9b\x26\x2b\xb6\x26\xbd\x61\x39\x99\x4d\x4f\x4b\xaf\xb9\x87\xc5\xd9\xe0\x21\xd9\x0d\x36\x2b\xaa\x1b\x7f\x89\x44\x31\xaa\x33\x88\x8e\x37\x2a\xbd\x2a\xf7\x52\x8b\x93\x7d\x68\x7a\x90\xcb\x92\x0f\xe0\x6f\x65\x3a\x37\x60\x4d\xab\xae\x99\xb4\x35\x23\x82\x25\x57\xa6\x8e\x71\x0c\xc3\xd1\xe9\x10\x75\x0c\x44\xd5\x4a\xa6\x8a\xe4\x1b\x98\x5a\x84\x68\x4e\xa2\xcb\xf1\xdd\x83\xcc\x4d\x87\xfb\x7c\xcd\x96\x82\x0a\x7d\xc3\xbc\xee\x64\x92\x6a\x47\x22\xee\x67\x10\xa2\x40\xe7\x0a\x01\xaa\xef\x16\x24\x31\xfb\x96\x09\xf2\x9c\x50\x4c\x4d\x6f\x33\xf0\x37\x59\x67\xc7\xcc\x92\x1f\x7e\x30\xce\x86\x7b\x91\x4c\x00\xc8\xa6\x8e\xbc\x94\x8e\xfa\x44\x00\xe8\x5e\x36\xef\xb8\xde\xb3\x21\x84\xa8\x99\x1f\x93\x97\x7a\xd5\x53\xed\x38\x58\x4e\x95\xe2\x5b\xb3\x4a\x4f\x53\xe6\x98\xec\x3b\x57\xdc\xdb\xb1\x7e\x07\xeb\x54\xd3\x61\xea\x7a\x64\x32\xa7\xea\x7a\x61\x77\x81\x71\x95\x42\x96\x6a\x79\x30\x08\x80\xe0\xa2\x80\x7e\x1d\x57\x6d\x30\xab\x80\xeb\xf8\x97\xf7\x5c\x17\xb1\xf9\x1a\x2e\x09\x83\xe5\x4d\x9a\xf5\xf0\x35\x7d\x5b\x1c\x89\x91\xab\xdc\xb6\x2c\x07\x15\x0a\x35\x87\x61\xe2\x92\x57\x56\x46\xa0\x11\x14\x3c\x70\x2a\x1c\xbd\xe6\x54\x56\x96\x7b\xec\x86\xf6\xf6\x55\x98\x3a\xd7\xed\x3d\x11\xac\x31\x95\x39\x22\x13\x11\xcd\xfc\x80\x70\xad\x57\xa6\xbb\xd7\x4d\x51\x1b\x4f\xd8\x49\x54\x83\xc8\x33\x8e\xef\x6c\x48\xb3\xf1\x45\x26\xa0\xf0\xc6\xb0\x60\xce\xc0\xf7\x77\x9e\x9f\x6e\xf5\x9f\x9f\xdf\xee\xc0\xc7\x1f\xce\xe1\xcf\x9f\xce\x6f\x4f\xb7\xb6\xcf\x5f\x89\xaf\xe2\xcf\xab\xe0\x2c\xfc\xbf\x81\x0b\x06\x93\x59\xd2\x53\xa4\x9e\xd2\xfe\x5f\xf7\xfb\xff\x09\x3d\xe1\xe3\x27\x1b\xbf\xfb\xe1\xe9\xb3\xc1\xde\xab\x9f\x2f\xfe\xeb\xfb\xed\xdd\xdf\xfa\xe7\xcf\xfe\x5c\xf7\x9f\xfb\xaf\x86\xf5\x53\xff\xfc\xfb\x56\xef\xc7\xed\x3b\xab\x3f\x78\x05\x10\x67\xe1\x83\x46\x04\x4f\x1d\x6a\xfc\xb3\xc5\xd3\xe1\xd9\xe0\x6c\x10\xf8\xa7\x67\x31\x00\x9e\x85\x40\x04\xae\xec\x54\x3c\x9c\x7f\xdf\xe9\xfd\x78\xd7\x5a\xc1\x18\x90\x9d\xf5\xcf\x36\xce\x06\x00\xb0\xd5\xbb\x73\xfa\xe7\x1c\x36\x07\xd3\xb6\x76\x23\x67\x11\xc8\xb0\xd3\x54\x80\xd9\x59\xf8\x79\x19\xbc\x8a\x9d\x76\x00\x8c\x7d\x7e\x0b\x01\x49\x42\x53\x77\x6a\x2a\xee\xdf\xf9\x17\xb7\xfd\xdb\x30\x78\x25\x5f\x5c\xd1\xfd\xe7\x4b\xcb\x28\x26\xcc\xba\x02\xb1\xbc\x28\xe9\x42\x97\x52\xbe\xd2\x85\x8e\xa6\xf4\x65\xe8\xae\x11\x53\x76\x1d\xcf\x67\x85\x1e\xf5\x9e\x5d\xbf\x81\xc7\xe6\x48\x3e\x1f\xcd\x92\xaa\x1e\xaa\x4f\x86\xbc\x9c\x89\x91\xe0\x5a\xc9\x9a\x49\x5d\xb9\xf9\x9f\xac\x87\xa8\x37\x0c\x40\x8d\x0f\xd2\xa4\x18\xe5\xb4\x8c\xff\x72\xec\x6f\x86\xa3\x2a\xdb\xac\x6f\x11\x9b\x92\xd5\xb0\x7e\x69\x68\xc2\xaa\xc3\x94\xe1\xd7\xd7\x37\x47\xb1\xbf\xe9\xa8\xe2\x66\xe0\xa4\x46\xbb\x1c\x90\x7a\x65\x0f\xc9\xf8\xba\x57\x7f\xed\x60\x58\x31\x6e\x66\x6e\xff\xba\x79\x02\x30\x2b\xfd\x18\x7d\xb4\x52\x24\x72\xcc\x35\x75\xe3\xa9\xd0\x2b\x3c\x60\xc3\xb0\xc3\x9d\xd0\xb8\x91\xe4\x75\x1c\x7e\xc9\x66\x39\xc8\x38\xfb\x27\xce\x35\xf8\x2e\xec\xaa\xec\x2b\x9d\x01\x1d\x0e\xe8\xe2\xd8\x90\x2c\x21\x8a\xea\xea\x59\x63\x20\xbe\x44\x51\xdf\xce\xef\x18\x98\xe1\x5b\x16\x6a\x90\xbe\xd8\x63\x36\x42\x56\xc4\x9c\xdb\x1f\x6b\x32\x43\x3a\x57\xda\x1d\xd0\x17\xa9\xed\x22\x59\x37\xf7\x21\xce\xa8\xbd\x08\x27\x9c\x77\xbc\x2d\xdf\xae\xd5\xb5\x28\xee\x91\xe6\x0d\xd5\x7f\x81\x76\x51\x7d\xc1\xbb\xb9\xf6\x3d\x54\xfd\xe5\x2f\xc7\x9f\x3f\x91\x57\xdd\xed\xa1\x96\xd0\x61\xdd\xaf\xee\x06\xc0\x6a\xef\x61\x8d\x14\x52\xc3\x99\xb7\x34\x01\xda\x48\x95\x0b\x85\x31\x2f\x08\x88\xfa\x8b\xb8\x96\xbc\xdb\x71\x33\xdf\x36\x51\xab\x43\xd9\xda\xb8\xd9\x4e\x9d\xf4\x15\xba\x34\xc1\xb1\x6c\x0d\x1d\x6b\x8f\x12\xb6\x40\x5c\x8c\xb1\xc6\xc8\x4b\x17\x9d\x40\x91\x36\x8e\x41\x88\xab\xf0\xdd\x45\x35\x2c\xe8\xfa\x0b\xbb\x87\xca\x25\x6b\x5b\xc5\x8e\x6e\x9a\x57\xac\xac\x46\xdb\x58\x18\xd8\x8e\x2c\xc2\x82\xf1\x6f\x78\xe9\xc1\xd8\x99\xd6\x4b\x13\x76\x30\xa1\xe5\xac\x2e\x43\x6e\x3f\xdf\x6a\x55\x1e\x4d\xf9\x54\x81\x07\xab\x6a\xb1\x1a\x65\xfd\x12\x07\xa2\x14\x61\xd9\x3f\xff\xfe\x8f\xba\xd4\x7a\xdf\xbb\x10\xb6\x7f\xda\x59\x6e\xb7\x30\xbd\x86\x40\xd5\xc9\xa6\x61\xa4\xd2\x40\x34\x38\x3d\xbb\xde\xda\xea\xc3\x9f\x9f\xe0\xff\x43\xf8\xb2\xfd\xf6\x7c\x20\x5f\x8e\x15\xe0\x56\x04\x37\x99\x8a\x77\x6f\x8e\x9a\x6e\xa2\x2d\x57\x53\x7a\x03\x7a\x1b\x5d\x3a\x07\xec\x52\xc7\x32\x84\x43\xfb\x10\xc3\x71\x33\x5e\xd7\x3c\x0d\xb3\x35\x42\xd8\x41\xfd\xd5\xd4\x4a\x15\x70\x8f\x78\x2f\xb0\xd6\xf7\x72\x63\xfb\xc5\x40\x7c\xf1\x1c\x1d\x37\x8b\xd5\x08\xea\x0b\x05\xcd\x5c\xef\xfd\x77\xb0\xc5\x25\x82\x7d\x01\x22\x62\x3d\xe2\xbd\x81\x70\x06\xcf\x05\xc7\x7c\x5a\x82\xcc\x8b\x24\x03\x47\xc0\xbe\xc5\x22\xae\x43\x7d\x9e\x57\xea\x3e\x54\x8f\x74\xc6\x99\x4b\xd4\xc6\x41\x24\xfc\x16\xef\x45\x9c\x5c\x91\x08\x55\x6f\x6f\x13\xe2\xc8\xb2\x22\xe2\xaf\x78\xfb\x6c\x93\x94\x79\xca\x54\xfb\xe6\x4b\x11\x86\xc8\x4b\x6f\x24\xcf\xc8\xbb\xa4\x7a\x3f\x1f\x09\x63\xc9\x18\xd1\x53\x60\x7a\x21\x16\xcb\x8a\xc5\x8d\x08\x1e\xbe\x18\xc0\x14\x2f\xbd\xae\x8b\x5c\x6e\x2e\xde\x2e\x51\xd4\x25\x79\x81\xf8\xba\x52\x6a\xd8\x3a\xf8\xed\x17\x41\xfc\x4e\x71\x95\x68\x16\x79\x29\xef\xe3\xa2\xfb\xf5\xef\xe2\xc1\xf7\x06\xbf\xd0\x2b\xca\xa3\x32\x29\x2a\x3e\x30\x52\x7a\x21\x61\xc3\x5f\x78\x4d\xa5\x6a\xca\xb3\xda\x2a\x2c\x4b\xd6\xff\xa6\x5d\x94\x75\xd4\xee\xa4\x81\xc5\x87\x4c\xf3\x21\x5c\xa1\x53\x92\xa0\xd0\xe8\xe0\x3d\x42\xa1\x45\x41\x3d\x3b\x43\x90\x59\xef\xa5\x35\x15\x3c\xed\x39\x64\x39\xbe\xaa\xd7\x61\x80\x7b\x0e\xf0\x88\xe2\xeb\x2d\x1e\x74\x36\x3a\xc4\xe5\xd4\x21\xf9\xa9\x01\x7e\x53\xb1\x77\x65\x3e\x2f\x44\xb8\xbd\xed\x76\x22\xc5\xee\x7b\x75\xf2\x1f\xec\x66\x92\x74\x75\xa4\x40\xe5\xa7\xf9\x6c\xc4\xf0\x2d\xd6\x76\x37\xaf\x6e\xb0\xe8\xed\xae\xce\x1e\xf5\x81\x8d\xab\x21\xd9\xdc\xec\x2d\x85\xf8\x8a\xbb\x01\x20\xc3\x16\x0c\x17\xfb\xa2\x30\xdc\x2e\xe9\xd6\xc3\xdb\xfd\xc0\xb0\x65\xb3\x43\x97\x1e\xd7\xd5\xf7\x69\x9e\x02\x97\x36\xc3\x56\x5f\x96\x67\x5f\x60\x52\x91\x39\xe8\x04\x90\x34\x2d\x19\x7f\x67\x3d\xdd\xad\x23\x62\x2d\xd1\x6f\x9b\x81\xc6\x4b\xe6\xf2\x00\x92\x7a\x1c\x34\xb6\x45\x66\xbd\xda\x3e\x8a\x5b\x52\x6d\xbc\x64\xde\x18\x6a\xf9\x6c\x8d\x61\x75\x91\xb0\xa7\x6d\x4f\xd0\x28\x6a\x19\x73\x50\xe4\xdc\x38\x01\x96\xba\xd9\x9e\xb2\xf9\x41\x89\xb6\x5b\x21\x2e\x94\x11\xbc\x1a\x05\x46\x34\x27\x29\x56\xc3\xd1\x9c\x82\x8f\x0c\x87\xd4\x0d\x49\x32\x14\xae\x90\x08\xbb\x8b\xfc\xac\xad\x6e\x68\x6e\x5b\x22\xcf\xc4\x0f\x42\xc8\x5b\x7e\x98\xa4\xfc\xc3\xce\x8e\xcd\x32\xdb\xb3\x46\xc8\x86\x53\xdd\x6c\xb2\xfc\xe9\x1a\xaf\xed\x4a\x13\x67\x09\xc6\x63\xc6\x24\xb0\xa4\x32\x32\x27\xa2\x71\x9c\x9b\xfc\xfb\xff\x73\xce\xa9\xb7\xb6\x1b\x47\x9d\x73\xc7\x4d\x3b\x69\xf2\x8a\xdb\x5a\x87\xd9\x9d\xfd\x13\x1b\x83\x01\xf9\x00\x2e\xad\xaa\x02\x70\x42\x4b\x46\x8a\x39\xde\xb3\x25\xe3\x32\x9f\xd9\x3f\x61\x22\xea\x37\xc7\xe2\x7b\xff\x18\xad\xfd\xa1\x48\x96\x84\xe4\x75\x99\x2f\x00\x86\x23\x32\x04\xc2\xc2\xae\xe8\x3b\xce\xe7\x65\xc4\xf0\x97\x4c\x14\x0e\x55\x26\x8a\x73\x2c\x12\xe5\xe3\x31\x60\x1d\xc8\x94\x4b\x4f\xce\x8c\xc9\xf6\x38\x14\xb9\x41\x24\xeb\x9b\xa2\x6a\x4f\xb0\x55\xd4\x63\x96\x55\x8a\x54\x5a\xd2\x9a\xb7\xf5\x2a\x59\x5d\xcd\xf1\xef\x3f\xd7\x33\x91\xcb\x75\xab\x8a\x22\x17\xad\x0b\x68\x7b\x76\x61\xc7\x09\x53\xeb\x02\xcf\x8e\xf3\xeb\x0e\x5c\x90\xa5\x8e\x79\x8b\x50\x38\xeb\x25\x13\xf4\x5e\x49\x40\x8c\x3b\x04\xd4\x07\x51\x63\x42\x9f\x40\xbc\xfe\xde\x29\x6e\xc0\xfa\x03\x8c\x8b\x61\x23\x51\x13\xdd\xaa\xde\x88\x81\x43\xca\x70\x1b\x16\x53\x54\x02\x50\x62\xb3\xc2\x3a\xb1\x63\x56\x6c\xbf\xe0\xdb\xb9\x30\xbf\xe1\x87\x2e\xa5\x57\xfd\x26\x4b\xe7\x55\x1b\xd1\x87\xbf\x64\xe1\x0b\xcd\x96\x3f\xcd\xc1\xa4\x67\xb0\x2e\xfe\x62\x0a\xc7\xf6\xff\x22\xfe\xfa\x76\x70\xd7\x0c\xc8\x73\x51\xed\x13\x8e\xa5\x2a\x77\x1a\xce\xa3\x3c\xd3\xa2\x48\x13\x16\xf7\x14\xdf\x33\x61\x2b\xeb\xfe\x1a\x8f\xac\xc6\xc6\x40\x89\x7d\xd5\xc0\xba\x64\xa0\x6e\x48\x20\xc2\x1b\xb4\x68\x00\x35\x7b\xd4\x75\xa7\xa7\xbd\xd8\xdd\x8e\xbb\x13\xc2\xdf\xd7\xb5\xa4\xa3\x38\xe8\xba\x41\x81\xfc\xd0\x30\xe0\x5b\xcd\x58\x89\xe1\x28\x0a\x47\x7d\xae\xb6\x0e\xb2\xa6\x88\xb8\x77\x2d\x56\xb0\x5a\x98\xe2\x95\xce\x26\xb0\xe9\x04\x78\x33\x92\xd6\x06\x34\x57\xc9\x2c\x07\x6f\x8c\x24\x15\x67\xe9\x98\xcc\x33\xf0\xea\xe5\xed\x0d\xd5\x0b\x38\x44\x05\xbb\x64\xe3\x39\x37\xe2\x2e\xee\xea\x4a\x52\x4a\x46\xe3\x1b\xcc\xc6\x31\x51\xe2\xb2\xf4\x32\x3c\xf8\xf0\xf9\xf8\xf0\x0d\xde\xde\x7d\x6c\xf4\xc3\xe6\x94\xc2\x20\xae\x59\xda\x27\xf5\x0a\x7b\x73\x67\xbf\x4b\x18\x38\x6f\x8c\xac\xaa\x35\xd7\x6f\xe2\x75\xa1\x6d\xde\x88\x69\x01\x48\x5d\xb0\x5e\x67\xc4\x5a\xce\xee\x23\xcb\xc4\xca\x61\xd8\xf9\xdf\xcb\xb0\x68\x29\xe3\x4a\x00\x00")
 
 func staticJavascriptsApplicationJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -294,7 +294,7 @@ func staticJavascriptsApplicationJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "static/javascripts/application.js", size: 13143, mode: os.FileMode(420), modTime: time.Unix(1737396896, 0)}
+	info := bindataFileInfo{name: "static/javascripts/application.js", size: 19171, mode: os.FileMode(420), modTime: time.Unix(1792425972, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -479,7 +479,7 @@ func staticJavascriptsUnderscoreJs() (*asset, error) {
 	return a, nil
 }
 
-var _staticStylesheetsApplicationCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x54\xed\x8a\xe3\x38\x10\xfc\x9f\xa7\xd0\x11\x0e\xee\xe0\x64\xec\x64\x72\xb9\xf3\xfc\x5c\xd8\x97\x58\x86\xd0\x96\xda\x76\x33\xb2\x24\xa4\xce\xc7\xec\x32\xef\xbe\x48\x71\x32\xce\x26\xd9\xec\x12\x02\x46\xaa\xea\x8f\x52\x75\x83\xf8\x36\x13\x42\x39\xe3\x42\x2d\xc8\xf6\x18\x88\x9f\x67\x42\x30\x1e\x58\x6a\x54\x2e\x00\x93\xb3\xb5\xd8\x5a\x8d\xc1\x90\xc5\xe7\xd9\xfb\x6c\x06\x75\xef\x76\x18\x6e\x92\xd3\x75\xd1\xb0\xcd\x97\x57\x71\xac\x1b\x43\x28\xa7\xf1\x1e\xbf\x75\x8e\xc7\xe8\x8d\x0b\x1a\x83\x64\xe7\x6b\x51\xf9\x83\x88\xce\x90\x16\xf3\x65\x99\x7e\xa9\xd2\x01\x42\x47\xf6\x08\x58\x95\xfe\x90\xce\x3c\x68\x4d\xb6\xab\xc5\xa2\xf4\x07\x91\xfe\x55\x39\x7e\xa5\xeb\xd6\x59\x96\x91\xbe\x62\x2d\xaa\x2a\x1d\xbd\xcf\x66\x85\x85\x5d\x03\x41\xc0\x83\xb2\xcf\xb8\x89\x02\x0f\xc4\x2a\x7c\x70\x5d\xc0\x18\x65\x22\x9e\x09\x89\xde\x1a\xb7\xaf\x05\x1a\x43\x3e\x52\x4c\xb5\xed\x7b\x62\x94\xd1\x83\xc2\x94\x75\x1f\xc0\xa7\xe3\x0f\x70\x4f\x5a\xa3\xcd\x81\xe7\x2d\xd9\xd4\x67\xdc\x44\x84\xa0\xfa\x1c\x7b\x4f\x9a\xfb\x5a\x2c\xfe\x2d\xc7\xce\xe6\x0c\x8d\xc1\xcd\x09\x2b\x58\x17\xca\x19\xe9\x81\xfb\xa9\xfe\x73\xa5\xd4\x43\x7c\xe4\xe0\x6c\x77\x41\x6b\xdb\xf6\x26\x2d\x93\x40\x25\x41\xa6\x75\xad\xee\x95\x35\xc5\x17\x0d\xe8\x0e\xa7\xb4\xaa\x2c\xff\xbc\x4f\x53\x6e\x18\x88\xa7\xf8\xf5\xf8\xd4\x59\x69\x30\xd4\xd9\x5a\x04\xea\x7a\xbe\x1f\x24\xa0\x77\x91\xd8\x85\xb7\x0b\x1d\xcb\xdf\x8a\xc4\xa1\x60\x8c\x2c\x03\x1a\x60\xd4\x39\x92\xf3\xa0\x88\xdf\x6a\x51\x16\x4f\x47\x43\x44\x4f\xd6\x8e\xe6\xd1\x14\xbd\x81\xb7\x5a\x34\xc6\xa9\xd7\x0f\x47\xd7\x62\xb1\xf2\x07\x01\x5b\x76\xa2\x2a\xc7\xaf\x4c\x4f\x39\x52\x56\x19\xd1\xa0\x3a\x65\x69\x40\xbd\x76\xc1\x6d\xad\x96\xa7\xa7\x59\xae\x57\xb0\x6e\xc5\x1f\x34\x78\x17\x18\xec\x58\xf2\xe0\x34\x98\x4d\x4b\x06\x45\x01\x06\x03\xcb\x88\xca\x59\x0d\x63\xe7\x13\x43\x3c\xc4\xff\xc4\x10\xc5\x28\x8a\x1c\x90\x41\xe6\x8a\x33\x6e\x3a\x7d\xcb\xd3\xf4\xdd\xc0\x8e\xf6\x1c\x67\x59\x66\xcd\xeb\x2c\xc5\x85\xf9\x37\xa4\x37\xca\x90\x6f\x1c\x84\xa3\x12\x1c\xc0\xc6\xd6\x85\xa1\x16\x51\x81\xc1\xbf\xca\x62\xfd\xf7\x8f\xad\x6f\x94\xb3\x8c\x96\x63\xfe\x00\xba\x7a\x8e\xf3\xc4\xdf\x22\xfd\x23\xa6\xa7\x3d\x1e\xf4\x76\xf0\xbf\xc0\x9f\x22\x07\x38\xc8\x1e\x8f\x5d\x3d\x95\xe7\xb6\x6e\xa0\xe7\x69\x95\x48\xbb\x1d\x9a\xcb\x95\x3b\x2f\xcb\x46\xfd\xa7\xee\xf2\xa2\x07\xfb\x45\x03\x83\x8c\x1c\x92\x86\xa4\x5f\x6e\x57\x7e\x85\xb4\x5b\x63\x5e\x2e\x72\x7d\x5e\xfe\xff\xa9\x5a\x24\x7f\xee\x30\x30\x29\x30\xa7\x69\x18\x48\x6b\x83\x79\xef\xa6\x01\xca\x1b\x30\xfb\x9f\x76\xf9\xf4\x2c\x09\xd9\xdc\xc8\xd9\xe8\xd7\x4b\x4d\x88\x93\x22\xd5\xea\x38\x76\xa7\xf9\x7f\xba\x9e\x42\x85\x96\x31\xa4\xee\xbf\x07\x00\x00\xff\xff\x41\xd0\x88\x66\xc6\x06\x00\x00")
+var _staticStylesheetsApplicationCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\x95\x55\xdb\x8a\xdb\x30\x10\x7d\xcf\x57\xa8\x84\x42\x0b\xb5\x71\x36\x9b\xa6\xf5\x3e\x16\xfa\x13\x65\x09\x63\x69\x6c\x0f\x2b\x4b\x46\x9a\x6c\xb2\x2d\xfd\xf7\x4a\x8a\x9d\x75\x9a\xa4\xe9\x12\x02\x66\x7c\xce\x5c\xce\x5c\x0c\xe2\xd7\x4c\x08\x69\xb5\x75\xa5\x20\xd3\xa2\x23\x7e\x08\x16\xc6\x3d\x67\x0a\xa5\x75\xc0\x64\x4d\x29\xb6\x46\xa1\xd3\x64\xf0\x61\xf6\x7b\x36\x83\xb2\xb5\xcf\xe8\x2e\x92\xe3\xeb\xbc\x62\x93\x5e\x9e\xf9\x31\x76\x70\x21\xad\xc2\x6b\xfc\xda\x5a\x1e\xbc\x57\xd6\x85\xc0\x19\xdb\xbe\x14\x8b\x7e\x2f\xbc\xd5\xa4\xc4\x7c\x59\xc4\x5f\xcc\xb4\x03\xd7\x90\x39\x00\x56\x45\xbf\x8f\xb6\x1e\x94\x22\xd3\x94\xe2\x2e\x18\x44\xfc\x2f\x8a\xe1\x29\xbe\xae\xad\xe1\xcc\xd3\x4f\x0c\x2e\x17\xd1\x14\x42\xe6\x06\x9e\x2b\x70\x02\x6e\xa4\x7d\xc4\x4d\x14\xb8\x21\x56\xde\x3b\xdb\x38\xf4\x3e\x8b\xc4\x23\x21\xd2\x6b\x6d\x77\xa5\x40\xad\xa9\xf7\xe4\x63\x6e\xbb\x96\x18\x33\xdf\x83\xc4\x18\x75\xe7\xa0\x8f\xe6\x57\x70\x4b\x4a\xa1\x49\x8e\xe7\x35\x99\x58\xa7\xdf\x78\x04\x27\xdb\xe4\x7b\x47\x8a\xdb\x50\xf9\xe7\x62\xa8\xec\x15\xc5\x8e\xa0\xc1\x4d\x4d\x7a\x54\x77\x00\x2f\x46\xe1\x06\x31\x1d\x35\x2d\x07\xf3\xb9\x0b\x6d\x41\x6d\x3a\xeb\x0e\xad\x1b\xe0\x1a\xeb\x13\x34\x43\xa5\x63\x98\x03\x47\xb0\xca\x43\x8f\xb3\x1e\xb8\x9d\x36\x7c\x2e\xa5\xbc\x89\xf7\xec\xac\x69\x4e\x68\x75\x5d\x5f\xa4\x25\x12\xc8\xd8\x81\x69\x6d\xab\x6b\x69\x4d\xf1\x79\x05\xaa\xc1\x13\x49\x8a\xe2\xfd\x75\x9a\xb4\x5d\x47\x3c\xc5\xaf\x07\x05\x53\x6b\x41\x53\x13\xc6\x20\xc9\x78\xdd\x89\xc3\xde\x7a\x62\xeb\x5e\x4e\x1a\x57\xbc\xdd\xd3\xa1\xb1\x27\xe9\x2f\xdf\xe4\x85\x5d\xce\xe8\x39\xa4\xa4\x81\x51\x25\x4f\x36\xcc\x20\xf1\x4b\x29\x8a\xfc\xfe\x30\xc7\xbe\x27\x63\x86\xc9\x51\xe4\x7b\x0d\xe1\x6d\xa5\xad\x7c\x7a\x9d\x9d\x50\xc0\x2a\x6c\x1a\x6c\xd9\xa6\x89\x48\x4f\x89\x1e\x63\xc4\xa8\x99\x47\x8d\x72\x8c\x52\x81\x7c\x6a\x9c\x0d\x1b\x93\x8d\x0d\x5e\xae\x57\xb0\xae\xc5\x3b\xea\x7a\xeb\x18\xcc\x90\x72\x67\x15\xe8\x38\xbc\x28\x72\xd0\xe8\xc2\x0a\x87\x95\x33\x0a\x06\xfd\x26\x63\x75\x13\xff\x8f\xb1\xca\x07\x51\xb2\x0e\x19\xb2\x94\x71\xc2\x4d\x8f\xc6\x72\x3c\x1a\x17\xb0\xc3\x90\x0f\x27\xe8\xea\x2a\x6d\x48\x6d\x64\x58\xfb\xca\x82\x3b\x28\xc1\x0e\x8c\xaf\xad\xeb\x4a\xe1\x65\x48\xf8\x43\x91\xaf\x3f\xfe\x5d\xfa\x26\x54\xc0\x68\xd8\xa7\x07\xa0\xb3\x76\x1c\x0f\xd5\x25\xd2\x27\x31\xb5\xb6\xb8\x57\xdb\xae\xff\x0f\xfe\x14\xd9\xc1\x3e\x6b\xf1\x50\xd5\x7d\x71\x2c\xeb\x02\x7a\x1e\x2f\x60\x66\xb6\x5d\x75\xfa\xa5\x98\x17\x45\x25\xbf\xc8\xab\xbc\x70\xfc\xcc\x0f\x05\x41\xd1\xd0\xa6\xa8\x21\xa9\xc7\xcb\x99\x9f\x21\xcd\x56\xeb\xc7\x93\x58\xdf\x97\x5f\xbf\x2d\xee\xe2\x7c\x86\x0b\xca\x14\x84\x1d\xb7\xa1\x0b\x87\x54\x63\xfa\x5c\xc4\x35\x4c\x87\x3b\xcd\x3f\x3d\x27\xeb\x51\x12\x32\xa9\x90\xe3\xa0\x9f\xdf\x62\x21\x46\x45\x16\xab\xc3\xda\x8d\x6b\x78\x7f\xbe\x85\x32\x74\x02\x5d\xac\xfe\x0f\xcd\x0e\xd1\x1b\x7d\x07\x00\x00")
 
 func staticStylesheetsApplicationCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -494,7 +494,7 @@ func staticStylesheetsApplicationCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "static/stylesheets/application.css", size: 1734, mode: os.FileMode(420), modTime: time.Unix(1737396896, 0)}
+	info := bindataFileInfo{name: "static/stylesheets/application.css", size: 1917, mode: os.FileMode(420), modTime: time.Unix(1792425716, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrNotCached    = errors.New("commit not in clone cache")
+)
+
+// CloneCache keeps bare clones of scanned repositories, so the web interface
+// can show the contents of files in the commits of findings after the scan
+type CloneCache struct {
+	sync.RWMutex
+
+	Dir string
+}
+
+// NewCloneCache creates the cache directory, which only the current user
+// can read as the clones contain the secrets that were found
+func NewCloneCache(dir string) (*CloneCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &CloneCache{Dir: dir}, nil
+}
+
+// Clone clones a repository next to its location in the cache, for a scan to
+// analyze. Concurrent scans of a repository each get their own clone, so
+// none is replaced while it's analyzed. Once the analysis is done, Keep
+// replaces the cached clone with it. It returns the clone and its location.
+func (c *CloneCache) Clone(ctx context.Context, repo *GithubRepository, depth int) (*git.Repository, string, error) {
+	location, err := c.location(*repo.Owner, *repo.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(location), 0700); err != nil {
+		return nil, "", err
+	}
+	dir, err := ioutil.TempDir(filepath.Dir(location), fmt.Sprintf(".%s_", *repo.Name))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp directory: %v", err)
+	}
+	repository, err := cloneRepository(ctx, dir, true, *repo.CloneURL, *repo.DefaultBranch, depth)
+	if err != nil {
+		return nil, "", err
+	}
+	return repository, dir, nil
+}
+
+// Keep replaces the cached clone of a repository with the clone at dir made
+// by Clone, which must no longer be used. The clone is removed if it can't
+// be kept.
+func (c *CloneCache) Keep(repo *GithubRepository, dir string) error {
+	location, err := c.location(*repo.Owner, *repo.Name)
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if err := os.RemoveAll(location); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	if err := os.Rename(dir, location); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	return nil
+}
+
+// File returns the contents of the file at path in a commit of a cached
+// clone. It returns ErrNotCached if the repository or commit isn't cached.
+func (c *CloneCache) File(owner string, name string, commit string, path string, maxSize int64) ([]byte, error) {
+	location, err := c.location(owner, name)
+	if err != nil {
+		return nil, ErrNotCached
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+	repository, err := git.PlainOpen(location)
+	if err == git.ErrRepositoryNotExists {
+		return nil, ErrNotCached
+	} else if err != nil {
+		return nil, err
+	}
+	commitObject, err := repository.CommitObject(plumbing.NewHash(commit))
+	if err == plumbing.ErrObjectNotFound {
+		// Commits of earlier scans or beyond -commit-depth may still be on GitHub
+		return nil, ErrNotCached
+	} else if err != nil {
+		return nil, err
+	}
+	file, err := commitObject.File(path)
+	if err == object.ErrFileNotFound {
+		return nil, ErrFileNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if file.Size > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// location returns the directory of a repository's clone in the cache
+func (c *CloneCache) location(owner string, name string) (string, error) {
+	for _, part := range []string{owner, name} {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return "", fmt.Errorf("invalid repository %s/%s", owner, name)
+		}
+	}
+	return filepath.Join(c.Dir, owner, name+".git"), nil
+}
+
+// InitCloneCache opens the cache directory given with -clone-cache
+func (s *Session) InitCloneCache() {
+	if *s.Options.CloneCache == "" {
+		return
+	}
+	cache, err := NewCloneCache(*s.Options.CloneCache)
+	if err != nil {
+		s.Out.Fatal("Failed to create clone cache %s: %s\n", *s.Options.CloneCache, err)
+	}
+	s.CloneCache = cache
+}
+
+// FileContents returns the contents of the file at path in a commit of a
+// repository, from the clone cache if it has the commit, or else from the
+// GitHub API. Only files of the session's findings are returned, so the
+// access token can't be used to read other files through the web interface.
+func (s *Session) FileContents(ctx context.Context, owner string, name string, commit string, path string) ([]byte, error) {
+	if !s.hasFindingIn(owner, name, commit, path) {
+		return nil, ErrFileNotFound
+	}
+	maxSize := *s.Options.MaxPreviewSize
+	if s.CloneCache != nil {
+		content, err := s.CloneCache.File(owner, name, commit, path, maxSize)
+		if err != ErrNotCached {
+			return content, err
+		}
+	}
+	return GetFileContents(ctx, s.GithubClient, owner, name, commit, path, maxSize)
+}
+
+// hasFindingIn reports whether the session has a finding in the file at path
+// in a commit of a repository, or in an archive at path
+func (s *Session) hasFindingIn(owner string, name string, commit string, path string) bool {
+	s.Lock()
+	defer s.Unlock()
+	for _, f := range s.Findings {
+		if f.CommitHash == commit && strings.EqualFold(f.RepositoryOwner, owner) && strings.EqualFold(f.RepositoryName, name) &&
+			(f.FilePath == path || ArchiveOuterPath(f.FilePath) == path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFileContentsOnlyServesFindings(t *testing.T) {
+	s := &Session{Findings: []*Finding{
+		{RepositoryOwner: "acme", RepositoryName: "api", CommitHash: "c1", FilePath: ".env"},
+		{RepositoryOwner: "acme", RepositoryName: "api", CommitHash: "c1", FilePath: "lib/app.jar!/application.properties"},
+	}}
+	tests := []struct {
+		owner, name, commit, path string
+		found                     bool
+	}{
+		{"acme", "api", "c1", ".env", true},
+		{"Acme", "API", "c1", ".env", true},
+		{"acme", "api", "c1", "lib/app.jar", true},
+		{"acme", "api", "c1", "lib/app.jar!/application.properties", true},
+		{"acme", "api", "c2", ".env", false},
+		{"acme", "api", "c1", "config.yml", false},
+		{"acme", "private", "c1", ".env", false},
+		{"other", "api", "c1", ".env", false},
+	}
+	for _, test := range tests {
+		if found := s.hasFindingIn(test.owner, test.name, test.commit, test.path); found != test.found {
+			t.Errorf("%s/%s %s %s: got %v, want %v", test.owner, test.name, test.commit, test.path, found, test.found)
+		}
+	}
+
+	// Files without findings are refused before the GitHub API is called
+	if _, err := s.FileContents(context.Background(), "acme", "private", "c1", "secrets.yml"); err != ErrFileNotFound {
+		t.Errorf("got error %v, want %v", err, ErrFileNotFound)
+	}
+}
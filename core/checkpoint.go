@@ -0,0 +1,175 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const DefaultCheckpointInterval = time.Minute
+
+// InitCheckpoints starts writing the session to the checkpoint file at the
+// checkpoint interval. Checkpoints are written to the file given with
+// -checkpoint, or the file being resumed from.
+func (s *Session) InitCheckpoints() {
+	location := *s.Options.Checkpoint
+	if location == "" {
+		location = *s.Options.Resume
+	}
+	if location == "" || s.Stats.Status == StatusFinished {
+		return
+	}
+	s.checkpointLocation = location
+	s.checkpointStop = make(chan struct{})
+	s.checkpointDone = make(chan struct{})
+
+	go func() {
+		defer close(s.checkpointDone)
+		ticker := time.NewTicker(*s.Options.CheckpointInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Checkpoint()
+			case <-s.checkpointStop:
+				return
+			}
+		}
+	}()
+}
+
+// Checkpoint writes the session to the checkpoint file, if checkpoints are
+// enabled. The file is replaced atomically so a crash while writing never
+// leaves a corrupt checkpoint behind.
+func (s *Session) Checkpoint() {
+	if s.checkpointLocation == "" {
+		return
+	}
+	if err := s.writeCheckpoint(s.checkpointLocation); err != nil {
+		s.Out.Error("Error writing checkpoint to %s: %s\n", s.checkpointLocation, err)
+		return
+	}
+	s.Out.Debug("Wrote checkpoint to %s\n", s.checkpointLocation)
+}
+
+// stopCheckpoints stops periodic checkpoints and writes a final checkpoint
+func (s *Session) stopCheckpoints() {
+	if s.checkpointStop == nil {
+		return
+	}
+	close(s.checkpointStop)
+	<-s.checkpointDone
+	s.checkpointStop = nil
+	s.Checkpoint()
+}
+
+func (s *Session) writeCheckpoint(location string) error {
+	s.Lock()
+	s.Stats.Lock()
+	data, err := json.Marshal(s)
+	s.Stats.Unlock()
+	s.Unlock()
+	if err != nil {
+		return err
+	}
+	return replaceFile(location, s.Key, data)
+}
+
+// replaceFile atomically replaces the file at location with data, encrypted
+// with key if it's set
+func replaceFile(location string, key *FileKey, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(location), filepath.Base(location)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	w, err := key.encrypt(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), FileMode); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), location)
+}
+
+// Resume loads the checkpoint at location into the session. Findings and
+// skipped files of repositories that were still being analyzed when the
+// checkpoint was written are dropped, as those repositories are analyzed
+// again. Commit and file counts may include some of their work twice.
+func (s *Session) Resume(location string) error {
+	if err := s.LoadFromFile(location); err != nil {
+		return err
+	}
+	if s.Stats == nil {
+		return fmt.Errorf("Checkpoint %s has no scan state to resume from.", location)
+	}
+
+	if s.Stats.Status != StatusAnalyzing && s.Stats.Status != StatusFinished {
+		// Gathering was interrupted, so targets and repositories are gathered
+		// again from the start
+		s.Targets = nil
+		s.Repositories = nil
+		s.Stats.Targets = 0
+	}
+
+	completed := make(map[string]bool)
+	for _, repository := range s.CompletedRepositories {
+		completed[repository] = true
+	}
+	s.completedRepositories = completed
+
+	var findings []*Finding
+	for _, finding := range s.Findings {
+		if completed[finding.RepositoryOwner+"/"+finding.RepositoryName] {
+			findings = append(findings, finding)
+		}
+	}
+	s.Findings = findings
+	s.Stats.Findings = len(findings)
+
+	var skippedFiles []*SkippedFile
+	for _, file := range s.SkippedFiles {
+		if completed[file.RepositoryOwner+"/"+file.RepositoryName] {
+			skippedFiles = append(skippedFiles, file)
+		}
+	}
+	s.SkippedFiles = skippedFiles
+	s.Stats.SkippedFiles = len(skippedFiles)
+	s.Stats.Repositories = len(completed)
+	return nil
+}
+
+// CompleteRepository records that analysis of the repository has ended,
+// whether or not it succeeded, so it is skipped when the scan is resumed.
+func (s *Session) CompleteRepository(repository *GithubRepository) {
+	s.Lock()
+	defer s.Unlock()
+	if s.completedRepositories == nil {
+		s.completedRepositories = make(map[string]bool)
+	}
+	if s.completedRepositories[*repository.FullName] {
+		return
+	}
+	s.completedRepositories[*repository.FullName] = true
+	s.CompletedRepositories = append(s.CompletedRepositories, *repository.FullName)
+}
+
+// IsRepositoryCompleted reports whether analysis of the repository ended in
+// the scan being resumed.
+func (s *Session) IsRepositoryCompleted(repository *GithubRepository) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.completedRepositories[*repository.FullName]
+}
@@ -1,24 +1,58 @@
 package core
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"regexp"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+
+	DefaultSeverity = SeverityMedium
+)
+
+// Severities lists all severities from most to least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// SeverityRank returns the position of severity in Severities, where lower
+// is more severe, or -1 if the severity is unknown.
+func SeverityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
 // Pattern represents a single detection pattern
 type Pattern struct {
-	Name        string `yaml:"name"`
-	Type        string `yaml:"type"`
-	Pattern     string `yaml:"pattern"`
-	Description string `yaml:"description"`
-	Comment     string `yaml:"comment"`
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`
+	Pattern     string   `yaml:"pattern"`
+	KeyPattern  string   `yaml:"key_pattern"`
+	Keywords    []string `yaml:"keywords"`
+	Verifier    string   `yaml:"verifier"`
+	Severity    string   `yaml:"severity"`
+	Description string   `yaml:"description"`
+	Comment     string   `yaml:"comment"`
 }
 
 // Config represents the root configuration
 type Config struct {
-	Patterns []Pattern `yaml:"patterns"`
+	Patterns  []Pattern                 `yaml:"patterns"`
+	Verifiers map[string]VerifierConfig `yaml:"verifiers"`
 }
 
 // LoadConfig loads patterns from the config file
@@ -33,6 +67,17 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, err
 	}
 
+	for i := range config.Patterns {
+		pattern := &config.Patterns[i]
+		if pattern.Severity == "" {
+			pattern.Severity = DefaultSeverity
+		}
+		pattern.Severity = strings.ToLower(pattern.Severity)
+		if SeverityRank(pattern.Severity) == -1 {
+			return nil, fmt.Errorf("pattern %s has unknown severity %q, must be one of: %s", pattern.Name, pattern.Severity, strings.Join(Severities, ", "))
+		}
+	}
+
 	return &config, nil
 }
 
@@ -43,35 +88,85 @@ func (c *Config) ConvertToSignatures() []Signature {
 	for _, pattern := range c.Patterns {
 		switch pattern.Type {
 		case "content":
+			if pattern.KeyPattern != "" {
+				signature := KeySignature{
+					name:        pattern.Name,
+					part:        PartKey,
+					key:         regexp.MustCompile(pattern.KeyPattern),
+					description: pattern.Description,
+					comment:     pattern.Comment,
+					severity:    pattern.Severity,
+					keywords:    pattern.Keywords,
+					verifier:    pattern.Verifier,
+				}
+				if pattern.Pattern != "" {
+					signature.match = regexp.MustCompile(pattern.Pattern)
+				}
+				signatures = append(signatures, signature)
+				continue
+			}
 			signatures = append(signatures, ContentSignature{
+				name:        pattern.Name,
 				part:        PartContent,
 				match:       regexp.MustCompile(pattern.Pattern),
 				description: pattern.Description,
 				comment:     pattern.Comment,
+				severity:    pattern.Severity,
+				keywords:    pattern.Keywords,
+				verifier:    pattern.Verifier,
 			})
 		case "extension":
 			signatures = append(signatures, SimpleSignature{
+				name:        pattern.Name,
 				part:        PartExtension,
 				match:       pattern.Pattern,
 				description: pattern.Description,
 				comment:     pattern.Comment,
+				severity:    pattern.Severity,
 			})
 		case "filename":
 			signatures = append(signatures, SimpleSignature{
+				name:        pattern.Name,
 				part:        PartFilename,
 				match:       pattern.Pattern,
 				description: pattern.Description,
 				comment:     pattern.Comment,
+				severity:    pattern.Severity,
 			})
 		case "path":
 			signatures = append(signatures, PatternSignature{
+				name:        pattern.Name,
 				part:        PartPath,
 				match:       regexp.MustCompile(pattern.Pattern),
 				description: pattern.Description,
 				comment:     pattern.Comment,
+				severity:    pattern.Severity,
 			})
 		}
 	}
 
 	return signatures
 }
+
+// RuleSetHash fingerprints the patterns that decide what is found, so
+// sessions scanned with different rules can be told apart. The order of
+// patterns and their descriptions and comments don't affect the hash.
+func (c *Config) RuleSetHash() string {
+	var rules []string
+	for _, pattern := range c.Patterns {
+		keywords := append([]string{}, pattern.Keywords...)
+		sort.Strings(keywords)
+		rules = append(rules, strings.Join([]string{
+			pattern.Name, pattern.Type, pattern.Pattern, pattern.KeyPattern,
+			strings.Join(keywords, ","), pattern.Severity,
+		}, "\x00"))
+	}
+	sort.Strings(rules)
+
+	h := sha256.New()
+	for _, rule := range rules {
+		io.WriteString(h, rule)
+		io.WriteString(h, "\n")
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"filippo.io/age"
+)
+
+const (
+	PassphraseEnvVariable = "GITROB_PASSPHRASE"
+
+	// FileMode is the mode of session, checkpoint and report files, which
+	// contain the locations of live secrets
+	FileMode = 0600
+)
+
+// ageHeader starts every file encrypted with age
+var ageHeader = []byte("age-encryption.org/v1\n")
+
+// FileKey encrypts and decrypts session, checkpoint and report files with
+// age, using either a passphrase or the X25519 identities in a key file.
+type FileKey struct {
+	recipients []age.Recipient
+	identities []age.Identity
+}
+
+// LoadFileKey returns the key to encrypt files with, read from the age key
+// file at keyFile, or derived from the passphrase in GITROB_PASSPHRASE. It
+// returns nil if neither is set, in which case files aren't encrypted.
+func LoadFileKey(keyFile string) (*FileKey, error) {
+	passphrase := os.Getenv(PassphraseEnvVariable)
+	if keyFile != "" && passphrase != "" {
+		return nil, fmt.Errorf("-key and %s can't be used together", PassphraseEnvVariable)
+	}
+
+	if passphrase != "" {
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return &FileKey{recipients: []age.Recipient{recipient}, identities: []age.Identity{identity}}, nil
+	}
+	if keyFile == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key file %s: %v", keyFile, err)
+	}
+	key := &FileKey{identities: identities}
+	for _, identity := range identities {
+		if x25519, ok := identity.(*age.X25519Identity); ok {
+			key.recipients = append(key.recipients, x25519.Recipient())
+		}
+	}
+	return key, nil
+}
+
+// CreateFile creates or truncates the file at location with FileMode,
+// encrypting everything written to it if key isn't nil.
+func CreateFile(location string, key *FileKey) (io.WriteCloser, error) {
+	f, err := os.OpenFile(location, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FileMode)
+	if err != nil {
+		return nil, err
+	}
+	// The mode only applies to new files, so existing ones are restricted too
+	if err := f.Chmod(FileMode); err != nil {
+		f.Close()
+		return nil, err
+	}
+	w, err := key.encrypt(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// ReadFile reads the file at location, decrypting it if it was encrypted.
+// Encrypted files are authenticated, so files that were tampered with fail
+// to decrypt instead of loading altered content.
+func ReadFile(location string, key *FileKey) ([]byte, error) {
+	data, err := ioutil.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, ageHeader) {
+		return data, nil
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%s is encrypted. Set %s or use -key to decrypt it", location, PassphraseEnvVariable)
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(data), key.identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("%s was encrypted with a different key or passphrase", location)
+		}
+		return nil, fmt.Errorf("failed to decrypt %s, it may have been tampered with: %v", location, err)
+	}
+	data, err = ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s, it may have been tampered with: %v", location, err)
+	}
+	return data, nil
+}
+
+// encrypt wraps f so everything written to it is encrypted, or returns f if
+// the key is nil. Closing the returned writer closes f.
+func (k *FileKey) encrypt(f *os.File) (io.WriteCloser, error) {
+	if k == nil {
+		return f, nil
+	}
+	if len(k.recipients) == 0 {
+		return nil, errors.New("the key file has no X25519 identities to encrypt files to")
+	}
+	w, err := age.Encrypt(f, k.recipients...)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFile{WriteCloser: w, f: f}, nil
+}
+
+type encryptedFile struct {
+	io.WriteCloser
+	f *os.File
+}
+
+func (e *encryptedFile) Close() error {
+	if err := e.WriteCloser.Close(); err != nil {
+		e.f.Close()
+		return err
+	}
+	return e.f.Close()
+}
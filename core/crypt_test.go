@@ -0,0 +1,124 @@
+package core
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gitrob_test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func passphraseKey(t *testing.T, passphrase string) *FileKey {
+	os.Setenv(PassphraseEnvVariable, passphrase)
+	defer os.Unsetenv(PassphraseEnvVariable)
+	key, err := LoadFileKey("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writeFile(t *testing.T, location string, key *FileKey, data []byte) {
+	w, err := CreateFile(location, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncryptedFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "session.json")
+	plaintext := []byte(`{"Findings": [{"FilePath": ".env"}]}`)
+	key := passphraseKey(t, "correct horse battery staple")
+	writeFile(t, location, key, plaintext)
+
+	encrypted, err := ioutil.ReadFile(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(encrypted, ageHeader) || bytes.Contains(encrypted, []byte(".env")) {
+		t.Fatal("file isn't encrypted")
+	}
+
+	tampered := func(data []byte) []byte {
+		data = append([]byte{}, data...)
+		data[len(data)-5] ^= 0x01
+		return data
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		key     *FileKey
+		wantErr string
+	}{
+		{"decrypts", encrypted, key, ""},
+		{"plaintext", plaintext, key, ""},
+		{"no key", encrypted, nil, "is encrypted"},
+		{"wrong passphrase", encrypted, passphraseKey(t, "wrong"), "different key"},
+		{"tampered", tampered(encrypted), key, "tampered"},
+		{"truncated", encrypted[:len(encrypted)-10], key, "tampered"},
+	}
+	for _, tt := range tests {
+		if err := ioutil.WriteFile(location, tt.data, FileMode); err != nil {
+			t.Fatal(err)
+		}
+		data, err := ReadFile(location, tt.key)
+		if tt.wantErr == "" {
+			if err != nil || !bytes.Equal(data, plaintext) {
+				t.Errorf("%s: got %q, %v", tt.name, data, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: got error %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCreateFileRestrictsExistingFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "report.html")
+	if err := ioutil.WriteFile(location, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(location, 0644)
+	writeFile(t, location, nil, []byte("new"))
+	info, err := os.Stat(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != FileMode {
+		t.Errorf("got mode %o, want %o", info.Mode().Perm(), FileMode)
+	}
+}
+
+func TestLoadFileKeyConflict(t *testing.T) {
+	os.Setenv(PassphraseEnvVariable, "secret")
+	defer os.Unsetenv(PassphraseEnvVariable)
+	if _, err := LoadFileKey("key.txt"); err == nil {
+		t.Error("expected an error for both -key and a passphrase")
+	}
+}
+
+func TestOutputRequiresNoKey(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	if _, err := ParseOptionsFrom(flags, []string{"-config", "config.yaml", "-output", "findings.jsonl", "-key", "key.txt"}); err == nil {
+		t.Error("expected an error for -output with -key")
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var csvRuleColumns = []string{"RuleType", "RuleSeverity", "RuleDescription", "RuleComment"}
+
+// WriteCSVReport writes one row per finding with every field of Finding,
+// followed by the metadata of the rule that produced it.
+func WriteCSVReport(w io.Writer, s *Session) error {
+	rules := make(map[string]ReportRule)
+	for _, rule := range ReportRules(s.Config, s.Findings) {
+		rules[rule.Id] = rule
+	}
+
+	writer := csv.NewWriter(w)
+	findingType := reflect.TypeOf(Finding{})
+	var header []string
+	for i := 0; i < findingType.NumField(); i++ {
+		header = append(header, findingType.Field(i).Name)
+	}
+	header = append(header, csvRuleColumns...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, finding := range s.Findings {
+		value := reflect.ValueOf(*finding)
+		var row []string
+		for i := 0; i < value.NumField(); i++ {
+			row = append(row, csvEscapeFormula(csvValue(value.Field(i).Interface())))
+		}
+		rule := rules[finding.RuleId]
+		row = append(row, rule.Type, rule.Severity, csvEscapeFormula(rule.Description), csvEscapeFormula(rule.Comment))
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvValue formats a field of a finding, leaving unknown dates empty
+func csvValue(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+// csvEscapeFormula prevents spreadsheet applications from evaluating values
+// such as commit messages as formulas.
+func csvEscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
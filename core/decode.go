@@ -0,0 +1,125 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	EncodingBase64 = "base64"
+	EncodingHex    = "hex"
+	EncodingURL    = "url"
+
+	DefaultDecodeMinLength = 20
+
+	// Minimum share of printable characters for decoded output to be
+	// considered text worth matching against.
+	decodePrintableRatio = 0.9
+)
+
+// DecodedBlob represents an encoded value found in file content and its decoded form
+type DecodedBlob struct {
+	Encoding string
+	Encoded  []byte
+	Content  []byte
+}
+
+type blobDecoder struct {
+	encoding string
+	pattern  *regexp.Regexp
+	decode   func(string) ([]byte, error)
+}
+
+var blobDecoders = []blobDecoder{
+	{EncodingBase64, regexp.MustCompile(`[A-Za-z0-9+/_-]{8,}={0,2}`), decodeBase64},
+	{EncodingHex, regexp.MustCompile(`(?:[0-9a-fA-F]{2}){4,}`), hex.DecodeString},
+	{EncodingURL, regexp.MustCompile(`[A-Za-z0-9%._~+=&/:-]*%[0-9A-Fa-f]{2}[A-Za-z0-9%._~+=&/:-]*`), decodeURL},
+}
+
+// DecodeBlobs finds base64, hex and URL-encoded values of at least minLength
+// characters in content and returns the ones that decode to printable text.
+func DecodeBlobs(content []byte, minLength int) []DecodedBlob {
+	var blobs []DecodedBlob
+	seen := make(map[string]bool)
+	for _, decoder := range blobDecoders {
+		for _, match := range decoder.pattern.FindAll(content, -1) {
+			if len(match) < minLength || seen[string(match)] {
+				continue
+			}
+			decoded, err := decoder.decode(string(match))
+			if err != nil || !isPrintable(decoded) {
+				continue
+			}
+			seen[string(match)] = true
+			blobs = append(blobs, DecodedBlob{
+				Encoding: decoder.encoding,
+				Encoded:  match,
+				Content:  decoded,
+			})
+		}
+	}
+	return blobs
+}
+
+// MatchEncoded runs the signatures against every value decoded from the file
+// content and returns the first match, with the result's Encoding set to the
+// encoding the matching value was found in. Only content signatures can
+// match, as the file path and structure are not changed by decoding.
+func MatchEncoded(file MatchFile, signatures []Signature, prefilter *Prefilter, timeout time.Duration, minLength int) MatchResult {
+	var result MatchResult
+	if file.Content == nil {
+		return result
+	}
+	for _, blob := range DecodeBlobs(file.Content, minLength) {
+		decoded := file
+		decoded.Content = blob.Content
+		decoded.Structured = nil
+		r := MatchSignatures(decoded, signatures, prefilter, timeout)
+		result.Skipped += r.Skipped
+		result.TimedOut += r.TimedOut
+		if r.Signature != nil {
+			result.Signature = r.Signature
+			result.Secret = r.Secret
+			result.Line = lineNumber(file.Content, bytes.Index(file.Content, blob.Encoded))
+			result.Encoding = blob.Encoding
+			return result
+		}
+	}
+	return result
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+func decodeURL(s string) ([]byte, error) {
+	decoded, err := url.QueryUnescape(s)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(decoded), nil
+}
+
+func isPrintable(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	var total, printable int
+	for _, r := range string(data) {
+		total++
+		if r == '\n' || r == '\r' || r == '\t' || (r >= 0x20 && r != 0x7f) {
+			printable++
+		}
+	}
+	return float64(printable)/float64(total) >= decodePrintableRatio
+}
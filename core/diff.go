@@ -0,0 +1,107 @@
+package core
+
+import "fmt"
+
+// Changes of findings compared to a baseline scan
+const (
+	ChangeNew       = "new"
+	ChangeFixed     = "fixed"
+	ChangeUnchanged = "unchanged"
+)
+
+// Changes lists all changes in the order they are reported
+var Changes = []string{ChangeNew, ChangeFixed, ChangeUnchanged}
+
+// DiffSessions compares the findings of a session with those of an earlier
+// baseline session. Findings are matched by their SecretKey, so a secret
+// that is still in a file is unchanged even if later commits found it again,
+// and findings without a secret fingerprint are matched by ID. The returned session
+// has the scan run and stats of the later session, the targets and
+// repositories of both, and a copy of every finding of both with its
+// Change set: new if only the later session has it, fixed if only the
+// baseline has it, and unchanged otherwise. Unchanged findings that haven't
+// been triaged since the baseline keep its triage.
+func DiffSessions(baseline *Session, current *Session) *Session {
+	diff := &Session{
+		Version:       current.Version,
+		SchemaVersion: SessionSchemaVersion,
+		ScanOptions:   current.ScanOptions,
+		RuleSet:       current.RuleSet,
+		Stats:         current.Stats,
+		Scan:          current.Scan,
+		Baseline:      baseline.Scan,
+	}
+	if diff.Stats == nil {
+		diff.Stats = &Stats{}
+	}
+	if diff.Baseline == nil {
+		diff.Baseline = &ScanRun{}
+		if baseline.Stats != nil {
+			diff.Baseline.StartedAt = baseline.Stats.StartedAt
+			diff.Baseline.FinishedAt = baseline.Stats.FinishedAt
+		}
+	}
+
+	for _, target := range append(append([]*GithubOwner{}, current.Targets...), baseline.Targets...) {
+		diff.AddTarget(target)
+	}
+	for _, repository := range append(append([]*GithubRepository{}, current.Repositories...), baseline.Repositories...) {
+		diff.AddRepository(repository)
+	}
+
+	baselineKeys := make(map[string]*Finding)
+	for _, finding := range baseline.Findings {
+		if _, ok := baselineKeys[finding.SecretKey()]; !ok {
+			baselineKeys[finding.SecretKey()] = finding
+		}
+	}
+	currentIds := make(map[string]bool)
+	currentKeys := make(map[string]bool)
+	for _, finding := range current.Findings {
+		if currentIds[finding.Id] {
+			continue
+		}
+		currentIds[finding.Id] = true
+		currentKeys[finding.SecretKey()] = true
+		f := *finding
+		f.Change = ChangeNew
+		if earlier, ok := baselineKeys[finding.SecretKey()]; ok {
+			f.Change = ChangeUnchanged
+			if !f.HasTriage() {
+				f.CopyTriage(earlier)
+			}
+		}
+		diff.Findings = append(diff.Findings, &f)
+	}
+	for _, finding := range baseline.Findings {
+		if currentIds[finding.Id] || currentKeys[finding.SecretKey()] {
+			continue
+		}
+		currentIds[finding.Id] = true
+		f := *finding
+		f.Change = ChangeFixed
+		diff.Findings = append(diff.Findings, &f)
+	}
+	return diff
+}
+
+// CountChanges returns the number of findings with each change
+func CountChanges(findings []*Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		if finding.Change != "" {
+			counts[finding.Change]++
+		}
+	}
+	return counts
+}
+
+// DiffSummary describes the changes in a session returned by DiffSessions,
+// e.g. "3 new, 1 fixed and 10 unchanged findings"
+func DiffSummary(findings []*Finding) string {
+	counts := CountChanges(findings)
+	total := counts[ChangeNew] + counts[ChangeFixed] + counts[ChangeUnchanged]
+	return fmt.Sprintf("%d new, %d fixed and %d unchanged %s",
+		counts[ChangeNew], counts[ChangeFixed], counts[ChangeUnchanged],
+		Pluralize(total, "finding", "findings"))
+}
@@ -0,0 +1,80 @@
+package core
+
+import "testing"
+
+func TestDiffSessions(t *testing.T) {
+	finding := func(id string, commit string, path string, fingerprint string) *Finding {
+		return &Finding{Id: id, CommitHash: commit, RepositoryOwner: "acme", RepositoryName: "api", FilePath: path, RuleId: "aws_key", SecretFingerprint: fingerprint}
+	}
+	baseline := &Session{Findings: []*Finding{
+		finding("a1", "c1", ".env", "secret-1"),            // still in .env
+		finding("b1", "c1", "config.yml", "secret-2"),      // removed
+		finding("d1", "c1", "id_rsa", ""),                  // file name finding, same commit
+		finding("e1", "c1", "old.pem", ""),                 // file name finding, gone
+		finding("f1", "c2", "deploy/.env", "secret-1"),     // same secret in another file
+		finding("g1", "c2", "config.yml", "secret-2-copy"), // removed
+	}}
+	baseline.Findings[0].TriageStatus = TriageFalsePositive
+	current := &Session{Findings: []*Finding{
+		finding("a2", "c3", ".env", "secret-1"), // found again in a later commit
+		finding("a2", "c3", ".env", "secret-1"), // duplicate
+		finding("d1", "c1", "id_rsa", ""),
+		finding("h2", "c3", ".env", "secret-3"),
+		finding("f2", "c3", "deploy/.env", "secret-1"),
+	}}
+
+	want := map[string]string{
+		"a2": ChangeUnchanged,
+		"d1": ChangeUnchanged,
+		"h2": ChangeNew,
+		"f2": ChangeUnchanged,
+		"b1": ChangeFixed,
+		"e1": ChangeFixed,
+		"g1": ChangeFixed,
+	}
+	diff := DiffSessions(baseline, current)
+	if len(diff.Findings) != len(want) {
+		t.Fatalf("got %d findings, want %d", len(diff.Findings), len(want))
+	}
+	for _, f := range diff.Findings {
+		if f.Change != want[f.Id] {
+			t.Errorf("%s: got %q, want %q", f.Id, f.Change, want[f.Id])
+		}
+	}
+	if diff.Findings[0].TriageStatus != TriageFalsePositive {
+		t.Errorf("unchanged finding didn't keep the triage of the baseline")
+	}
+	if current.Findings[0].Change != "" || current.Findings[0].TriageStatus != "" {
+		t.Errorf("findings of the compared sessions were changed")
+	}
+}
+
+func TestSecretKey(t *testing.T) {
+	a := &Finding{Id: "a", RepositoryOwner: "acme", RepositoryName: "api", FilePath: ".env", RuleId: "aws_key", SecretFingerprint: "f"}
+	b := *a
+	b.Id, b.CommitHash = "b", "other"
+	if a.SecretKey() != b.SecretKey() {
+		t.Error("findings of the same secret in different commits have different keys")
+	}
+	for _, change := range []func(f *Finding){
+		func(f *Finding) { f.RepositoryName = "web" },
+		func(f *Finding) { f.FilePath = "prod.env" },
+		func(f *Finding) { f.RuleId = "generic" },
+		func(f *Finding) { f.SecretFingerprint = "g" },
+	} {
+		c := *a
+		change(&c)
+		if c.SecretKey() == a.SecretKey() {
+			t.Errorf("%+v has the same key as %+v", c, *a)
+		}
+	}
+	// Parts are separated, so they can't run into each other
+	c := *a
+	c.RepositoryOwner, c.RepositoryName = "acmeapi", ""
+	if c.SecretKey() == a.SecretKey() {
+		t.Error("keys of different repositories collide")
+	}
+	if d := (&Finding{Id: "x"}); d.SecretKey() != "x" {
+		t.Errorf("finding without fingerprint: got %s", d.SecretKey())
+	}
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	EventFinding = "finding" // A finding was made, or its verification or triage changed
+	EventStats   = "stats"   // Stats that changed since the last stats event
+	EventPhase   = "phase"   // The scan moved on to another status
+
+	StatsEventInterval      = 500 * time.Millisecond
+	EventsHeartbeatInterval = 15 * time.Second
+
+	// Events buffered per subscriber. Subscribers that fall further behind
+	// are disconnected, and reconnect to catch up.
+	eventsBufferSize = 256
+)
+
+// Event is a named, JSON-encoded message for subscribers of an EventBroker
+type Event struct {
+	Name string
+	Data []byte
+}
+
+// EventBroker fans events out to subscribers, such as web interface clients
+// connected to /events
+type EventBroker struct {
+	sync.Mutex
+
+	subscribers map[chan Event]bool
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{subscribers: make(map[chan Event]bool)}
+}
+
+// Subscribe returns a channel that receives all events published from now
+// on. The channel is closed if the subscriber falls behind.
+func (b *EventBroker) Subscribe() chan Event {
+	b.Lock()
+	defer b.Unlock()
+	events := make(chan Event, eventsBufferSize)
+	b.subscribers[events] = true
+	return events
+}
+
+func (b *EventBroker) Unsubscribe(events chan Event) {
+	b.Lock()
+	defer b.Unlock()
+	if b.subscribers[events] {
+		delete(b.subscribers, events)
+		close(events)
+	}
+}
+
+// Publish sends an event with data encoded as JSON to all subscribers. It
+// does nothing on a nil broker, so sessions without a web interface can
+// publish unconditionally.
+func (b *EventBroker) Publish(name string, data interface{}) {
+	if b == nil {
+		return
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	for events := range b.subscribers {
+		select {
+		case events <- Event{Name: name, Data: encoded}:
+		default:
+			delete(b.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// InitEvents starts publishing changes of the session's stats
+func (s *Session) InitEvents() {
+	s.Events = NewEventBroker()
+	go func() {
+		previous := s.statsSnapshot()
+		ticker := time.NewTicker(StatsEventInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			current := s.statsSnapshot()
+			delta := make(map[string]interface{})
+			for key, value := range current {
+				if !reflect.DeepEqual(previous[key], value) {
+					delta[key] = value
+				}
+			}
+			if len(delta) == 0 {
+				continue
+			}
+			if status, ok := delta["Status"]; ok {
+				s.Events.Publish(EventPhase, map[string]interface{}{"Status": status})
+			}
+			s.Events.Publish(EventStats, delta)
+			previous = current
+		}
+	}()
+}
+
+// statsSnapshot returns the session's stats as they are encoded to JSON
+func (s *Session) statsSnapshot() map[string]interface{} {
+	s.Stats.Lock()
+	data, err := json.Marshal(s.Stats)
+	s.Stats.Unlock()
+	snapshot := make(map[string]interface{})
+	if err == nil {
+		json.Unmarshal(data, &snapshot)
+	}
+	return snapshot
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const FingerprintKeyEnvVariable = "GITROB_FINGERPRINT_KEY"
+
+// SecretFingerprint returns a stable identifier for a secret that doesn't
+// reveal the secret itself. It's keyed, so short secrets can't be recovered
+// from reports by hashing guesses without the key.
+func SecretFingerprint(key []byte, secret string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(secret))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// LoadFingerprintKey returns the key of this installation in location,
+// which is generated if it doesn't exist yet
+func LoadFingerprintKey(location string) ([]byte, error) {
+	data, err := ioutil.ReadFile(location)
+	if os.IsNotExist(err) {
+		return createFingerprintKey(location)
+	} else if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(string(data))), nil
+}
+
+func createFingerprintKey(location string) ([]byte, error) {
+	if err := os.MkdirAll(filepath.Dir(location), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(location, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FileMode)
+	if os.IsExist(err) {
+		// Created by another process in the meantime
+		return LoadFingerprintKey(location)
+	} else if err != nil {
+		return nil, err
+	}
+	key := randomToken()
+	if _, err := f.WriteString(key + "\n"); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return []byte(key), f.Close()
+}
+
+// DefaultFingerprintKeyFile returns the location of the fingerprint key of
+// this installation, in the user's config directory
+func DefaultFingerprintKeyFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitrob", "fingerprint.key"), nil
+}
+
+// InitFingerprintKey loads the key secrets are fingerprinted with: the value
+// of GITROB_FINGERPRINT_KEY, so fingerprints of several installations can be
+// compared, or else the key of this installation
+func (s *Session) InitFingerprintKey() {
+	if key := os.Getenv(FingerprintKeyEnvVariable); key != "" {
+		s.FingerprintKey = []byte(key)
+		return
+	}
+	location, err := DefaultFingerprintKeyFile()
+	if err == nil {
+		s.FingerprintKey, err = LoadFingerprintKey(location)
+	}
+	if err != nil {
+		s.Out.Fatal("Failed to load the key to fingerprint secrets with: %s. Set %s to use another key.\n", err, FingerprintKeyEnvVariable)
+	}
+}
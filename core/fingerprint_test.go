@@ -0,0 +1,58 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecretFingerprint(t *testing.T) {
+	key := []byte("key")
+	if SecretFingerprint(key, "hunter2") != SecretFingerprint(key, "hunter2") {
+		t.Errorf("fingerprint isn't stable")
+	}
+	if SecretFingerprint(key, "hunter2") == SecretFingerprint(key, "hunter3") {
+		t.Errorf("different secrets have the same fingerprint")
+	}
+	if SecretFingerprint(key, "hunter2") == SecretFingerprint([]byte("other"), "hunter2") {
+		t.Errorf("fingerprint doesn't depend on the key")
+	}
+	// An unkeyed SHA-256 of the secret would let it be brute-forced
+	if SecretFingerprint(key, "hunter2") == "f52fbd32b2b3b86ff88ef6c490628285f482af15ddcb29541f94bcf526a3f6c7" {
+		t.Errorf("fingerprint is an unkeyed hash of the secret")
+	}
+}
+
+func TestLoadFingerprintKey(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "gitrob", "fingerprint.key")
+
+	key, err := LoadFingerprintKey(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != 64 {
+		t.Errorf("got a key of %d bytes, want 64", len(key))
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != FileMode {
+		t.Errorf("got mode %v, want %v", info.Mode().Perm(), os.FileMode(FileMode))
+	}
+
+	again, err := LoadFingerprintKey(location)
+	if err != nil || string(again) != string(key) {
+		t.Errorf("got key %q, %v, want the generated key %q", again, err, key)
+	}
+
+	if err := ioutil.WriteFile(location, []byte("configured\n"), FileMode); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := LoadFingerprintKey(location); err != nil || string(key) != "configured" {
+		t.Errorf("got key %q, %v, want %q", key, err, "configured")
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+// Exit codes of a scan, so CI pipelines can fail builds on findings
+const (
+	ExitCodeClean    = 0 // No scan errors and no findings above the -fail-on and -max-findings thresholds
+	ExitCodeFindings = 1 // Findings above the thresholds
+	ExitCodeError    = 2 // Invalid options, or errors that left part of the scan incomplete
+)
+
+// CountFindings returns the number of findings at least as severe as
+// severity, or of all findings if severity is empty. Findings without a
+// known severity count as DefaultSeverity, and findings triaged as false
+// positives, accepted or fixed aren't counted.
+func CountFindings(findings []*Finding, severity string) int {
+	threshold := SeverityRank(severity)
+	count := 0
+	for _, finding := range findings {
+		if finding.IsTriaged() {
+			continue
+		}
+		if severity == "" {
+			count++
+			continue
+		}
+		rank := SeverityRank(finding.Severity)
+		if rank == -1 {
+			rank = SeverityRank(DefaultSeverity)
+		}
+		if rank <= threshold {
+			count++
+		}
+	}
+	return count
+}
+
+// FindingsAboveThreshold reports whether the findings exceed the thresholds
+// set with -fail-on and -max-findings, along with the number of findings
+// counted against them. Without either option no findings fail the scan.
+func (s *Session) FindingsAboveThreshold() (bool, int) {
+	if *s.Options.FailOn == "" && *s.Options.MaxFindings < 0 {
+		return false, 0
+	}
+	count := CountFindings(s.Findings, *s.Options.FailOn)
+	max := *s.Options.MaxFindings
+	if max < 0 {
+		max = 0
+	}
+	return count > max, count
+}
+
+// ExitCode returns the exit code for the result of the session. Findings
+// above the thresholds take precedence over scan errors.
+func (s *Session) ExitCode() int {
+	if above, _ := s.FindingsAboveThreshold(); above {
+		return ExitCodeFindings
+	}
+	if s.Stats.Errors > 0 {
+		return ExitCodeError
+	}
+	return ExitCodeClean
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -15,11 +17,15 @@ import (
 
 const (
 	EmptyTreeCommitId = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+	DefaultMaxFileSize    = 1024 * 1024
+	DefaultMaxPreviewSize = 100 * 1024
 )
 
-func CloneRepository(url *string, branch *string, depth int) (*git.Repository, string, error) {
+var ErrFileTooLarge = errors.New("file exceeds maximum size")
+
+func CloneRepository(ctx context.Context, url *string, branch *string, depth int) (*git.Repository, string, error) {
 	urlVal := *url
-	branchVal := *branch
 
 	// Create temp directory with a more specific prefix
 	dir, err := ioutil.TempDir("", fmt.Sprintf("gitrob_repo_%s_", filepath.Base(urlVal)))
@@ -27,10 +33,20 @@ func CloneRepository(url *string, branch *string, depth int) (*git.Repository, s
 		return nil, "", fmt.Errorf("failed to create temp directory: %v", err)
 	}
 
-	repository, err := git.PlainClone(dir, false, &git.CloneOptions{
-		URL:           urlVal,
+	repository, err := cloneRepository(ctx, dir, false, urlVal, *branch, depth)
+	if err != nil {
+		return nil, "", err
+	}
+	return repository, dir, nil
+}
+
+// cloneRepository clones the branch of a repository into dir, and removes
+// dir if the clone fails
+func cloneRepository(ctx context.Context, dir string, bare bool, url string, branch string, depth int) (*git.Repository, error) {
+	repository, err := git.PlainCloneContext(ctx, dir, bare, &git.CloneOptions{
+		URL:           url,
 		Depth:         depth,
-		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branchVal)),
+		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
 		SingleBranch:  true,
 		Tags:          git.NoTags,
 	})
@@ -39,12 +55,12 @@ func CloneRepository(url *string, branch *string, depth int) (*git.Repository, s
 	if err != nil {
 		os.RemoveAll(dir)
 		if err.Error() == "remote repository is empty" {
-			return nil, "", err
+			return nil, err
 		}
-		return nil, "", fmt.Errorf("failed to clone repository: %v", err)
+		return nil, fmt.Errorf("failed to clone repository: %v", err)
 	}
 
-	return repository, dir, nil
+	return repository, nil
 }
 
 func GetRepositoryHistory(repository *git.Repository) ([]*object.Commit, error) {
@@ -64,7 +80,7 @@ func GetRepositoryHistory(repository *git.Repository) ([]*object.Commit, error)
 	return commits, nil
 }
 
-func GetChanges(commit *object.Commit, repo *git.Repository) (object.Changes, error) {
+func GetChanges(ctx context.Context, commit *object.Commit, repo *git.Repository) (object.Changes, error) {
 	parentCommit, err := GetParentCommit(commit, repo)
 	if err != nil {
 		return nil, err
@@ -80,7 +96,7 @@ func GetChanges(commit *object.Commit, repo *git.Repository) (object.Changes, er
 		return nil, err
 	}
 
-	changes, err := object.DiffTree(parentCommitTree, commitTree)
+	changes, err := object.DiffTreeContext(ctx, parentCommitTree, commitTree)
 	if err != nil {
 		return nil, err
 	}
@@ -119,44 +135,30 @@ func GetChangeAction(change *object.Change) string {
 	}
 }
 
-func GetChangeContent(change *object.Change) ([]byte, error) {
-	action := GetChangeAction(change)
-	if action == "Delete" {
-		return nil, nil
-	}
-
+// GetChangeContent returns the contents of the file after the change, or nil
+// for deletions and binary files. Rules match the whole file as of the
+// commit rather than its patch, so deleted lines aren't matched, structured
+// files parse, and line numbers are lines of the file.
+func GetChangeContent(change *object.Change, maxFileSize int64) ([]byte, error) {
 	// Skip SVG files and other potentially problematic formats
 	path := GetChangePath(change)
 	if strings.HasSuffix(strings.ToLower(path), ".svg") {
 		return nil, nil
 	}
 
-	patch, err := change.Patch()
-	if err != nil {
+	_, to, err := change.Files()
+	if err != nil || to == nil {
 		return nil, err
 	}
-
-	var content strings.Builder
-	for _, filePatch := range patch.FilePatches() {
-		if filePatch.IsBinary() {
-			continue
-		}
-
-		// Skip if total content size is too large (>1MB)
-		var totalSize int
-		for _, chunk := range filePatch.Chunks() {
-			totalSize += len(chunk.Content())
-			if totalSize > 1024*1024 {
-				return nil, nil
-			}
-		}
-
-		for _, chunk := range filePatch.Chunks() {
-			content.WriteString(chunk.Content())
-		}
+	// Binary files aren't matched at any size, so they aren't reported as
+	// too large either
+	if binary, err := to.IsBinary(); err != nil || binary {
+		return nil, err
 	}
-
-	return []byte(content.String()), nil
+	if to.Size > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	return readFile(to)
 }
 
 func GetChangePath(change *object.Change) string {
@@ -166,3 +168,30 @@ func GetChangePath(change *object.Change) string {
 	}
 	return change.To.Name
 }
+
+func GetChangeBlob(change *object.Change, maxSize int64) ([]byte, error) {
+	if GetChangeAction(change) == "Delete" {
+		return nil, nil
+	}
+
+	_, to, err := change.Files()
+	if err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, nil
+	}
+	if to.Size > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	return readFile(to)
+}
+
+func readFile(file *object.File) ([]byte, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
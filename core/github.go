@@ -2,6 +2,9 @@ package core
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
 
 	"github.com/google/go-github/github"
 )
@@ -130,3 +133,37 @@ func GetRepository(owner string, name string, client *github.Client) (*GithubRep
 		Homepage:      repo.Homepage,
 	}, nil
 }
+
+// GetFileContents returns the contents of the file at path in a commit of a
+// repository through the contents API, so private repositories can be read
+// with the access token
+func GetFileContents(ctx context.Context, client *github.Client, owner string, name string, commit string, path string, maxSize int64) ([]byte, error) {
+	opt := &github.RepositoryContentGetOptions{Ref: commit}
+	file, _, resp, err := client.Repositories.GetContents(ctx, owner, name, path, opt)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if file == nil || file.GetType() != "file" {
+		return nil, ErrFileNotFound
+	}
+	if int64(file.GetSize()) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	// Files over 1 MB are returned without content and have to be downloaded
+	if file.GetEncoding() == "none" {
+		reader, err := client.Repositories.DownloadContents(ctx, owner, name, path, opt)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return ioutil.ReadAll(io.LimitReader(reader, maxSize))
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
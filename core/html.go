@@ -0,0 +1,308 @@
+package core
+
+import (
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+type htmlReport struct {
+	Name         string
+	Version      string
+	GeneratedAt  string
+	Stats        *Stats
+	Severities   []string
+	Baseline     *ScanRun
+	Diff         string
+	Changes      []string
+	Repositories []htmlRepository
+	Findings     []htmlFinding
+}
+
+type htmlRepository struct {
+	Name   string
+	Counts []int
+	Total  int
+}
+
+type htmlFinding struct {
+	*Finding
+	Severity   string
+	Repository string
+	ShortHash  string
+	Lines      []htmlSnippetLine
+}
+
+type htmlSnippetLine struct {
+	Number  int
+	Text    string
+	Matched bool
+}
+
+// WriteHTMLReport writes the findings of a session as a single HTML page
+// with all styles and scripts inlined, so it can be viewed offline and
+// attached to tickets.
+func WriteHTMLReport(w io.Writer, s *Session) error {
+	report := htmlReport{
+		Name:        strings.Title(Name),
+		Version:     Version,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Stats:       s.Stats,
+		Severities:  Severities,
+		Baseline:    s.Baseline,
+		Changes:     Changes,
+	}
+	if s.Baseline != nil {
+		report.Diff = DiffSummary(s.Findings)
+	}
+	if report.Stats == nil {
+		report.Stats = &Stats{}
+	}
+
+	repositories, grouped := groupFindings(s.Findings)
+	for _, repository := range repositories {
+		r := htmlRepository{Name: repository}
+		for _, severity := range Severities {
+			count := len(grouped[repository][severity])
+			r.Counts = append(r.Counts, count)
+			r.Total += count
+		}
+		report.Repositories = append(report.Repositories, r)
+	}
+
+	for _, severity := range Severities {
+		for _, repository := range repositories {
+			for _, finding := range grouped[repository][severity] {
+				report.Findings = append(report.Findings, htmlFinding{
+					Finding:    finding,
+					Severity:   severity,
+					Repository: repository,
+					ShortHash:  shortCommitHash(finding.CommitHash),
+					Lines:      htmlSnippetLines(finding),
+				})
+			}
+		}
+	}
+
+	return htmlReportTemplate.Execute(w, report)
+}
+
+func htmlSnippetLines(finding *Finding) []htmlSnippetLine {
+	if finding.Snippet == "" {
+		return nil
+	}
+	var lines []htmlSnippetLine
+	for i, text := range strings.Split(finding.Snippet, "\n") {
+		number := finding.SnippetStart() + i
+		lines = append(lines, htmlSnippetLine{
+			Number:  number,
+			Text:    text,
+			Matched: number == finding.LineNumber,
+		})
+	}
+	return lines
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"title": strings.Title,
+}).Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif; font-size: 14px; color: #212529; margin: 0; }
+main { max-width: 1200px; margin: 0 auto; padding: 1rem 1.5rem; }
+h1 { font-size: 1.75rem; margin: 0.5rem 0 0; }
+h2 { font-size: 1.25rem; margin: 2rem 0 0.75rem; }
+a { color: #0366d6; text-decoration: none; }
+a:hover { text-decoration: underline; }
+code, pre { font-family: SFMono-Regular, Menlo, Consolas, monospace; font-size: 12px; }
+.muted { color: #6c757d; }
+.cards { display: flex; flex-wrap: wrap; gap: 0.75rem; margin-top: 1rem; }
+.card { flex: 1; min-width: 120px; border: 1px solid #dee2e6; border-radius: 4px; padding: 0.75rem; text-align: center; }
+.card strong { display: block; font-size: 1.5rem; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 0.4rem 0.5rem; border-top: 1px solid #dee2e6; vertical-align: top; }
+th { border-bottom: 2px solid #dee2e6; white-space: nowrap; }
+td.count, th.count { text-align: right; }
+tr.finding { cursor: pointer; }
+tr.finding:hover { background: #f5f5f5; }
+tr.details td { background: #fafbfc; }
+tr.details th { border: 0; padding: 0.2rem 0.5rem 0.2rem 0; width: 8rem; vertical-align: top; }
+tr.details td td { background: none; border: 0; padding: 0.2rem 0; }
+.badge { display: inline-block; padding: 0.15em 0.45em; border-radius: 3px; font-size: 11px; font-weight: bold; text-transform: uppercase; color: #fff; background: #6c757d; }
+.severity-critical { background: #721c24; }
+.severity-high { background: #dc3545; }
+.severity-medium { background: #fd7e14; }
+.severity-low { background: #17a2b8; }
+.severity-info { background: #6c757d; }
+.action-Insert { background: #28a745; }
+.action-Modify { background: #007bff; }
+.action-Delete { background: #dc3545; }
+.change-new { background: #dc3545; }
+.change-fixed { background: #28a745; }
+.change-unchanged { background: #adb5bd; }
+.filters { display: flex; flex-wrap: wrap; gap: 0.5rem; margin-bottom: 0.75rem; }
+.filters input, .filters select { font-size: 13px; padding: 0.3rem; border: 1px solid #ced4da; border-radius: 3px; }
+.filters input { flex: 1; min-width: 200px; }
+pre.snippet { background: #f6f8fa; border: 1px solid #e1e4e8; border-radius: 3px; padding: 0.5rem 0; margin: 0; overflow-x: auto; }
+pre.snippet span { display: block; padding: 0 0.5rem; }
+pre.snippet span.matched { background: #fff5b1; }
+pre.snippet em { display: inline-block; width: 3.5em; color: #6c757d; font-style: normal; text-align: right; margin-right: 1em; user-select: none; }
+[hidden] { display: none !important; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Name}} report</h1>
+<p class="muted">Generated by {{.Name}} v{{.Version}} on {{.GeneratedAt}}{{if not .Stats.StartedAt.IsZero}} from a scan started {{.Stats.StartedAt.Format "2006-01-02 15:04:05 MST"}}{{end}}.</p>
+{{if .Baseline}}<p>Compared to the scan started {{.Baseline.StartedAt.Format "2006-01-02 15:04:05 MST"}}: {{.Diff}}.</p>{{end}}
+
+<div class="cards">
+  <div class="card"><strong>{{len .Findings}}</strong>Findings</div>
+  <div class="card"><strong>{{.Stats.Files}}</strong>Files</div>
+  <div class="card"><strong>{{.Stats.Commits}}</strong>Commits</div>
+  <div class="card"><strong>{{.Stats.Repositories}}</strong>Repositories</div>
+  <div class="card"><strong>{{.Stats.Targets}}</strong>Targets</div>
+</div>
+
+<h2>Repositories</h2>
+{{if .Repositories}}
+<table>
+  <thead>
+    <tr><th>Repository</th>{{range .Severities}}<th class="count">{{title .}}</th>{{end}}<th class="count">Total</th></tr>
+  </thead>
+  <tbody>
+  {{range .Repositories}}
+    <tr><td><a href="#" data-repository="{{.Name}}">{{.Name}}</a></td>{{range .Counts}}<td class="count">{{.}}</td>{{end}}<td class="count"><strong>{{.Total}}</strong></td></tr>
+  {{end}}
+  </tbody>
+</table>
+{{else}}
+<p class="muted">No findings.</p>
+{{end}}
+
+{{if .Findings}}
+<h2>Findings <span class="muted" id="findings_count"></span></h2>
+<div class="filters">
+  <input type="search" id="filter_search" placeholder="Search path, rule, author, commit message...">
+  <select id="filter_severity">
+    <option value="">All severities</option>
+    {{range .Severities}}<option value="{{.}}">{{title .}}</option>{{end}}
+  </select>
+  <select id="filter_repository">
+    <option value="">All repositories</option>
+    {{range .Repositories}}<option value="{{.Name}}">{{.Name}}</option>{{end}}
+  </select>
+  <select id="filter_action">
+    <option value="">All actions</option>
+    <option value="Insert">Insert</option>
+    <option value="Modify">Modify</option>
+    <option value="Delete">Delete</option>
+  </select>
+  {{if .Baseline}}
+  <select id="filter_change">
+    <option value="">All changes</option>
+    {{range .Changes}}<option value="{{.}}">{{title .}}</option>{{end}}
+  </select>
+  {{end}}
+</div>
+<table id="findings">
+  <thead>
+    <tr><th>Severity</th><th>Finding</th><th>Path</th><th>Commit</th><th>Repository</th><th>Action</th></tr>
+  </thead>
+  {{range .Findings}}
+  <tbody class="finding-group" data-severity="{{.Severity}}" data-repository="{{.Repository}}" data-action="{{.Action}}" data-change="{{.Change}}" data-search="{{.RuleId}} {{.Description}} {{.FilePath}} {{.CommitHash}} {{.CommitAuthor}} {{.CommitMessage}}">
+    <tr class="finding">
+      <td><span class="badge severity-{{.Severity}}">{{.Severity}}</span></td>
+      <td>{{.Description}}{{if .Change}} <span class="badge change-{{.Change}}">{{.Change}}</span>{{end}}</td>
+      <td><code>{{.FilePath}}{{if .LineNumber}}:{{.LineNumber}}{{end}}</code></td>
+      <td><code><a href="{{.CommitUrl}}" rel="noopener noreferrer" target="_blank">{{.ShortHash}}</a></code></td>
+      <td>{{.Repository}}</td>
+      <td><span class="badge action-{{.Action}}">{{.Action}}</span></td>
+    </tr>
+    <tr class="details" hidden>
+      <td colspan="6">
+        <table>
+          <tr><th>Rule</th><td><code>{{.RuleId}}</code></td></tr>
+          {{if .Comment}}<tr><th>Comment</th><td>{{.Comment}}</td></tr>{{end}}
+          <tr><th>File</th><td><a href="{{.FileUrl}}" rel="noopener noreferrer" target="_blank">{{.FilePath}}</a></td></tr>
+          <tr><th>Author</th><td>{{.CommitAuthor}}</td></tr>
+          <tr><th>Message</th><td><em>{{.CommitMessage}}</em></td></tr>
+          {{if .Encoding}}<tr><th>Encoding</th><td>Matched in <code>{{.Encoding}}</code> encoded value</td></tr>{{end}}
+          {{if .Verification}}<tr><th>Verification</th><td>{{.Verification}}</td></tr>{{end}}
+          <tr><th>ID</th><td><code>{{.Id}}</code></td></tr>
+          {{if .Lines}}<tr><th>Snippet</th><td><pre class="snippet">{{range .Lines}}<span{{if .Matched}} class="matched"{{end}}><em>{{.Number}}</em>{{.Text}}</span>{{end}}</pre></td></tr>{{end}}
+        </table>
+      </td>
+    </tr>
+  </tbody>
+  {{end}}
+</table>
+{{end}}
+</main>
+
+<script>
+(function() {
+  var groups = Array.prototype.slice.call(document.querySelectorAll("tbody.finding-group"));
+  if (groups.length === 0) {
+    return;
+  }
+  var search = document.getElementById("filter_search");
+  var severity = document.getElementById("filter_severity");
+  var repository = document.getElementById("filter_repository");
+  var action = document.getElementById("filter_action");
+  var change = document.getElementById("filter_change");
+  var count = document.getElementById("findings_count");
+
+  groups.forEach(function(group) {
+    group.querySelector("tr.finding").addEventListener("click", function(e) {
+      if (e.target.tagName === "A") {
+        return;
+      }
+      var details = group.querySelector("tr.details");
+      details.hidden = !details.hidden;
+    });
+  });
+
+  function filter() {
+    var query = search.value.toLowerCase();
+    var shown = 0;
+    groups.forEach(function(group) {
+      var visible = (!severity.value || group.dataset.severity === severity.value) &&
+        (!repository.value || group.dataset.repository === repository.value) &&
+        (!action.value || group.dataset.action === action.value) &&
+        (!change || !change.value || group.dataset.change === change.value) &&
+        (!query || group.dataset.search.toLowerCase().indexOf(query) !== -1);
+      group.hidden = !visible;
+      if (visible) {
+        shown++;
+      }
+    });
+    count.textContent = shown === groups.length ? "(" + groups.length + ")" : "(" + shown + " of " + groups.length + ")";
+  }
+
+  [search, severity, repository, action, change].forEach(function(input) {
+    if (!input) {
+      return;
+    }
+    input.addEventListener("input", filter);
+    input.addEventListener("change", filter);
+  });
+  Array.prototype.forEach.call(document.querySelectorAll("a[data-repository]"), function(link) {
+    link.addEventListener("click", function(e) {
+      e.preventDefault();
+      repository.value = link.dataset.repository;
+      filter();
+      document.getElementById("findings").scrollIntoView();
+    });
+  });
+  filter();
+})();
+</script>
+</body>
+</html>
+`))
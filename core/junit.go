@@ -0,0 +1,219 @@
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const junitCleanTestCase = "no findings"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the findings of a session as JUnit XML. Every
+// scanned repository is a test suite, and every file a rule matched in is a
+// failed test case listing the commits it was found in, or a skipped one if
+// all its findings were triaged. Repositories without
+// findings get a single passing test case, and repositories whose analysis
+// timed out an errored one.
+func WriteJUnitReport(w io.Writer, s *Session) error {
+	repositories, grouped := junitGroupFindings(s)
+	timedOut := make(map[string]bool)
+	for _, repository := range s.TimedOutRepositories {
+		timedOut[repository] = true
+	}
+
+	report := junitTestSuites{Name: Name}
+	if s.Stats != nil && !s.Stats.StartedAt.IsZero() && !s.Stats.FinishedAt.IsZero() {
+		report.Time = fmt.Sprintf("%.3f", s.Stats.FinishedAt.Sub(s.Stats.StartedAt).Seconds())
+	}
+	for _, repository := range repositories {
+		suite := junitTestSuite{Name: repository}
+		if s.Stats != nil && !s.Stats.StartedAt.IsZero() {
+			suite.Timestamp = s.Stats.StartedAt.Format("2006-01-02T15:04:05")
+		}
+		for _, findings := range grouped[repository] {
+			testCase := junitFindingsTestCase(repository, findings)
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			} else {
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		if timedOut[repository] {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "analysis",
+				ClassName: repository,
+				Error: &junitProblem{
+					Message: "Analysis exceeded the repository timeout",
+					Type:    "timeout",
+					Text:    "Only part of the repository's history was scanned.",
+				},
+			})
+			suite.Errors++
+		}
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: junitCleanTestCase, ClassName: repository})
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitGroupFindings returns the sorted full names of all scanned
+// repositories and of repositories with findings, and the findings of each
+// repository grouped by rule and file, in order of first occurrence. Findings
+// fixed since a baseline scan are left out.
+func junitGroupFindings(s *Session) ([]string, map[string][][]*Finding) {
+	grouped := make(map[string][][]*Finding)
+	for _, repository := range s.Repositories {
+		if repository.FullName != nil {
+			grouped[*repository.FullName] = nil
+		}
+	}
+	for _, repository := range s.TimedOutRepositories {
+		if _, ok := grouped[repository]; !ok {
+			grouped[repository] = nil
+		}
+	}
+
+	indexes := make(map[string]int)
+	for _, finding := range s.Findings {
+		if finding.Change == ChangeFixed {
+			continue
+		}
+		repository := fmt.Sprintf("%s/%s", finding.RepositoryOwner, finding.RepositoryName)
+		key := strings.Join([]string{repository, finding.RuleId, finding.FilePath}, "\x00")
+		if i, ok := indexes[key]; ok {
+			grouped[repository][i] = append(grouped[repository][i], finding)
+			continue
+		}
+		indexes[key] = len(grouped[repository])
+		grouped[repository] = append(grouped[repository], []*Finding{finding})
+	}
+
+	var repositories []string
+	for repository := range grouped {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+	return repositories, grouped
+}
+
+// junitFindingsTestCase returns the test case of findings of a rule in a
+// file. It fails unless all findings were triaged, like -fail-on does.
+func junitFindingsTestCase(repository string, findings []*Finding) junitTestCase {
+	first := findings[0]
+	rule := first.RuleId
+	if rule == "" {
+		rule = first.Description
+	}
+	severity := first.Severity
+	if severity == "" {
+		severity = DefaultSeverity
+	}
+
+	var text strings.Builder
+	if first.Comment != "" {
+		fmt.Fprintf(&text, "%s\n\n", first.Comment)
+	}
+	fmt.Fprintf(&text, "Found in %d %s:\n", len(findings), Pluralize(len(findings), "commit", "commits"))
+	for _, finding := range findings {
+		fmt.Fprintf(&text, "\n%s %s", strings.ToUpper(finding.Action), finding.CommitHash)
+		if finding.LineNumber > 0 {
+			fmt.Fprintf(&text, " (line %d)", finding.LineNumber)
+		}
+		if finding.Change != "" {
+			fmt.Fprintf(&text, " [%s]", finding.Change)
+		}
+		fmt.Fprintf(&text, "\n  Author.....: %s\n", finding.CommitAuthor)
+		fmt.Fprintf(&text, "  Message....: %s\n", TruncateString(finding.CommitMessage, 100))
+		if finding.Encoding != "" {
+			fmt.Fprintf(&text, "  Encoding...: %s\n", finding.Encoding)
+		}
+		if finding.Verification != "" {
+			fmt.Fprintf(&text, "  Verified...: %s\n", finding.Verification)
+		}
+		if finding.IsTriaged() {
+			fmt.Fprintf(&text, "  Triage.....: %s\n", finding.TriageStatus)
+		}
+		fmt.Fprintf(&text, "  File URL...: %s\n", finding.FileUrl)
+		fmt.Fprintf(&text, "  Commit URL.: %s\n", finding.CommitUrl)
+		fmt.Fprintf(&text, "  ID.........: %s\n", finding.Id)
+	}
+
+	testCase := junitTestCase{
+		Name:      fmt.Sprintf("%s: %s", rule, first.FilePath),
+		ClassName: repository,
+		File:      ArchiveOuterPath(first.FilePath),
+		Line:      first.LineNumber,
+	}
+	problem := &junitProblem{
+		Message: fmt.Sprintf("[%s] %s", severity, first.Description),
+		Type:    rule,
+		Text:    text.String(),
+	}
+	for _, finding := range findings {
+		if !finding.IsTriaged() {
+			testCase.Failure = problem
+			return testCase
+		}
+	}
+	problem.Message = "Triaged: " + problem.Message
+	testCase.Skipped = problem
+	return testCase
+}
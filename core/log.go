@@ -2,6 +2,7 @@ package core
 
 import (
   "fmt"
+  "io"
   "os"
   "sync"
 
@@ -30,6 +31,7 @@ type Logger struct {
 
   debug  bool
   silent bool
+  output io.Writer
 }
 
 func (l *Logger) SetSilent(s bool) {
@@ -40,6 +42,10 @@ func (l *Logger) SetDebug(d bool) {
   l.debug = d
 }
 
+func (l *Logger) SetOutput(w io.Writer) {
+  l.output = w
+}
+
 func (l *Logger) Log(level int, format string, args ...interface{}) {
   l.Lock()
   defer l.Unlock()
@@ -49,14 +55,18 @@ func (l *Logger) Log(level int, format string, args ...interface{}) {
     return
   }
 
+  output := l.output
+  if output == nil {
+    output = os.Stdout
+  }
   if c, ok := LogColors[level]; ok {
-    c.Printf(format, args...)
+    c.Fprintf(output, format, args...)
   } else {
-    fmt.Printf(format, args...)
+    fmt.Fprintf(output, format, args...)
   }
 
   if level == FATAL {
-    os.Exit(1)
+    os.Exit(ExitCodeError)
   }
 }
 
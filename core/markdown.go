@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ", "\r", "", "[", "\\[", "]", "\\]", "`", "\\`")
+
+// WriteMarkdownReport writes a summary of the findings of a session as
+// Markdown tables, grouped by repository and severity.
+func WriteMarkdownReport(w io.Writer, s *Session) error {
+	repositories, grouped := groupFindings(s.Findings)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s report\n\n", strings.Title(Name))
+	fmt.Fprintf(&b, "%d %s in %d %s, generated by %s v%s on %s.\n\n",
+		len(s.Findings), Pluralize(len(s.Findings), "finding", "findings"),
+		len(repositories), Pluralize(len(repositories), "repository", "repositories"),
+		Name, Version, time.Now().Format(time.RFC3339))
+	if s.Baseline != nil {
+		fmt.Fprintf(&b, "Compared to the scan started %s: %s.\n\n",
+			s.Baseline.StartedAt.Format(time.RFC3339), DiffSummary(s.Findings))
+	}
+
+	if len(s.Findings) == 0 {
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	b.WriteString("| Repository |")
+	for _, severity := range Severities {
+		fmt.Fprintf(&b, " %s |", strings.Title(severity))
+	}
+	b.WriteString(" Total |\n|---|")
+	for range Severities {
+		b.WriteString("---:|")
+	}
+	b.WriteString("---:|\n")
+	for _, repository := range repositories {
+		fmt.Fprintf(&b, "| %s |", markdownEscaper.Replace(repository))
+		total := 0
+		for _, severity := range Severities {
+			count := len(grouped[repository][severity])
+			total += count
+			fmt.Fprintf(&b, " %d |", count)
+		}
+		fmt.Fprintf(&b, " %d |\n", total)
+	}
+
+	for _, repository := range repositories {
+		fmt.Fprintf(&b, "\n## %s\n", markdownEscaper.Replace(repository))
+		for _, severity := range Severities {
+			findings := grouped[repository][severity]
+			if len(findings) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", strings.Title(severity))
+			b.WriteString("| Finding | Path | Commit | Author | Action |")
+			if s.Baseline != nil {
+				b.WriteString(" Change |")
+			}
+			b.WriteString("\n|---|---|---|---|---|")
+			if s.Baseline != nil {
+				b.WriteString("---|")
+			}
+			b.WriteString("\n")
+			for _, finding := range findings {
+				fmt.Fprintf(&b, "| %s | [%s](%s) | [%s](%s) | %s | %s |",
+					markdownEscaper.Replace(finding.Description),
+					markdownEscaper.Replace(finding.FilePath), finding.FileUrl,
+					shortCommitHash(finding.CommitHash), finding.CommitUrl,
+					markdownEscaper.Replace(finding.CommitAuthor),
+					finding.Action)
+				if s.Baseline != nil {
+					fmt.Fprintf(&b, " %s |", finding.Change)
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// groupFindings groups findings by repository full name and severity, and
+// returns the sorted repository names along with the groups. Findings
+// without a severity, e.g. from old session files, count as medium.
+func groupFindings(findings []*Finding) ([]string, map[string]map[string][]*Finding) {
+	var repositories []string
+	grouped := make(map[string]map[string][]*Finding)
+	for _, finding := range findings {
+		repository := fmt.Sprintf("%s/%s", finding.RepositoryOwner, finding.RepositoryName)
+		if _, ok := grouped[repository]; !ok {
+			grouped[repository] = make(map[string][]*Finding)
+			repositories = append(repositories, repository)
+		}
+		severity := finding.Severity
+		if SeverityRank(severity) == -1 {
+			severity = DefaultSeverity
+		}
+		grouped[repository][severity] = append(grouped[repository][severity], finding)
+	}
+	sort.Strings(repositories)
+	return repositories, grouped
+}
+
+func shortCommitHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeSessions combines sessions scanned separately, e.g. of different
+// organizations, into one session. Targets and repositories are merged by
+// ID, findings by ID keeping the triage of any duplicate, and skipped files
+// by repository, commit and path. The stats are recomputed from the merged
+// session, except for counts that can't be, like commits and files, which
+// are summed. The returned warnings
+// describe inputs that may not be comparable, such as sessions made by
+// different versions of Gitrob or with different rules, referring to each
+// session by its name in names.
+func MergeSessions(sessions []*Session, names []string) (*Session, []string) {
+	merged := &Session{Version: Version, SchemaVersion: SessionSchemaVersion, Stats: &Stats{Status: StatusFinished, Progress: 100}}
+	var warnings []string
+
+	var versions, ruleSets []string
+	findingIds := make(map[string]*Finding)
+	skippedFiles := make(map[string]bool)
+	timedOut := make(map[string]bool)
+	completed := make(map[string]bool)
+	for i, s := range sessions {
+		versions = append(versions, s.Version)
+		ruleSets = append(ruleSets, s.RuleSet)
+
+		for _, target := range s.Targets {
+			merged.AddTarget(target)
+		}
+		for _, repository := range s.Repositories {
+			merged.AddRepository(repository)
+		}
+		for _, finding := range s.Findings {
+			if first, ok := findingIds[finding.Id]; ok {
+				if !first.HasTriage() {
+					first.CopyTriage(finding)
+				}
+				continue
+			}
+			// Copied, so carrying triage doesn't change the merged sessions
+			f := *finding
+			findingIds[f.Id] = &f
+			merged.Findings = append(merged.Findings, &f)
+		}
+		for _, file := range s.SkippedFiles {
+			key := strings.Join([]string{file.RepositoryOwner, file.RepositoryName, file.CommitHash, file.FilePath}, "\x00")
+			if skippedFiles[key] {
+				continue
+			}
+			skippedFiles[key] = true
+			merged.SkippedFiles = append(merged.SkippedFiles, file)
+		}
+		for _, repository := range s.TimedOutRepositories {
+			if !timedOut[repository] {
+				timedOut[repository] = true
+				merged.TimedOutRepositories = append(merged.TimedOutRepositories, repository)
+			}
+		}
+		for _, repository := range s.CompletedRepositories {
+			if !completed[repository] {
+				completed[repository] = true
+				merged.CompletedRepositories = append(merged.CompletedRepositories, repository)
+			}
+		}
+
+		if s.Stats == nil {
+			continue
+		}
+		if !s.Stats.StartedAt.IsZero() && (merged.Stats.StartedAt.IsZero() || s.Stats.StartedAt.Before(merged.Stats.StartedAt)) {
+			merged.Stats.StartedAt = s.Stats.StartedAt
+		}
+		if s.Stats.FinishedAt.After(merged.Stats.FinishedAt) {
+			merged.Stats.FinishedAt = s.Stats.FinishedAt
+		}
+		if s.Stats.Status != StatusFinished {
+			warnings = append(warnings, fmt.Sprintf("%s is unfinished (status %s), so its findings may be incomplete", names[i], s.Stats.Status))
+		}
+		merged.Stats.Commits += s.Stats.Commits
+		merged.Stats.Files += s.Stats.Files
+		merged.Stats.SkippedRegexes += s.Stats.SkippedRegexes
+		merged.Stats.TimedOutRegexes += s.Stats.TimedOutRegexes
+		merged.Stats.Errors += s.Stats.Errors
+	}
+
+	if mergeDistinct(versions) > 1 {
+		warnings = append(warnings, "sessions were generated by different versions of Gitrob: "+mergeDescribeGroups(versions, names, "unknown"))
+	}
+	if mergeDistinct(ruleSets) > 1 {
+		warnings = append(warnings, "sessions were scanned with different rules: "+mergeDescribeGroups(ruleSets, names, "not recorded"))
+	}
+
+	merged.Stats.Targets = len(merged.Targets)
+	merged.Stats.Repositories = len(merged.Repositories)
+	merged.Stats.Findings = len(merged.Findings)
+	merged.Stats.SkippedFiles = len(merged.SkippedFiles)
+	merged.Stats.TimedOutRepositories = len(merged.TimedOutRepositories)
+	merged.Scan = NewScanRun(merged.Stats.StartedAt)
+	merged.Scan.FinishedAt = merged.Stats.FinishedAt
+	if mergeDistinct(ruleSets) == 1 {
+		merged.RuleSet = ruleSets[0]
+	}
+	return merged, warnings
+}
+
+// mergeDescribeGroups describes which sessions share each value, given the
+// value and name of every session, e.g. "2.0.0 (a.json, c.json), 1.1.2 (b.json)"
+func mergeDescribeGroups(values []string, names []string, empty string) string {
+	var order []string
+	groups := make(map[string][]string)
+	for i, value := range values {
+		if _, ok := groups[value]; !ok {
+			order = append(order, value)
+		}
+		groups[value] = append(groups[value], names[i])
+	}
+
+	var descriptions []string
+	for _, value := range order {
+		description := value
+		if description == "" {
+			description = empty
+		} else if len(description) > 12 {
+			description = description[:12]
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", description, strings.Join(groups[value], ", ")))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// mergeDistinct returns the number of distinct values
+func mergeDistinct(values []string) int {
+	distinct := make(map[string]bool)
+	for _, value := range values {
+		distinct[value] = true
+	}
+	return len(distinct)
+}
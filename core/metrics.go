@@ -0,0 +1,320 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Upper bounds in seconds of the buckets of the clone duration histogram
+var CloneDurationBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Metrics records what Stats doesn't count for the /metrics endpoint: how
+// long clones take, how many fail, the GitHub API rate limit and how busy
+// the workers are. Scans started by gitrob serve share its Metrics.
+type Metrics struct {
+	sync.Mutex
+
+	cloneBuckets  []int
+	cloneCount    int
+	cloneSeconds  float64
+	cloneErrors   int
+	rateLimit     int
+	rateRemaining int
+	rateReset     time.Time
+	rateKnown     bool
+	pool          *RepositoryPool
+	retired       scanTotals // Totals of scans no longer kept in memory
+}
+
+// scanTotals sums the stats and findings of scans
+type scanTotals struct {
+	targets, repositories, commits, files, findings, errors, timedOut, skipped int
+
+	rules map[ruleKey]int
+}
+
+type ruleKey struct{ rule, severity string }
+
+func (t *scanTotals) add(s *Session) {
+	s.Stats.Lock()
+	t.targets += s.Stats.Targets
+	t.repositories += s.Stats.Repositories
+	t.commits += s.Stats.Commits
+	t.files += s.Stats.Files
+	t.findings += s.Stats.Findings
+	t.errors += s.Stats.Errors
+	t.timedOut += s.Stats.TimedOutRepositories
+	t.skipped += s.Stats.SkippedFiles
+	s.Stats.Unlock()
+
+	s.Lock()
+	for _, finding := range s.Findings {
+		t.rules[ruleKey{finding.RuleId, finding.Severity}]++
+	}
+	s.Unlock()
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		cloneBuckets: make([]int, len(CloneDurationBuckets)),
+		retired:      scanTotals{rules: make(map[ruleKey]int)},
+	}
+}
+
+// Retire adds the counts of a finished scan that is about to be dropped from
+// memory to the totals, so counters keep increasing
+func (m *Metrics) Retire(s *Session) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.retired.add(s)
+}
+
+// ObserveClone records the duration of a clone, and whether it failed
+func (m *Metrics) ObserveClone(duration time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	seconds := duration.Seconds()
+	for i, bound := range CloneDurationBuckets {
+		if seconds <= bound {
+			m.cloneBuckets[i]++
+		}
+	}
+	m.cloneCount++
+	m.cloneSeconds += seconds
+	if failed {
+		m.cloneErrors++
+	}
+}
+
+// ObserveRateLimit records the rate limit headers of a GitHub API response
+func (m *Metrics) ObserveRateLimit(header http.Header) {
+	if m == nil {
+		return
+	}
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	m.Lock()
+	defer m.Unlock()
+	m.rateLimit = limit
+	m.rateRemaining = remaining
+	m.rateReset = time.Unix(reset, 0)
+	m.rateKnown = true
+}
+
+// SetPool sets the pool whose worker utilisation is exported
+func (m *Metrics) SetPool(pool *RepositoryPool) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.pool = pool
+}
+
+// rateLimitTransport records the rate limit of every GitHub API response
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.metrics.ObserveRateLimit(resp.Header)
+	}
+	return resp, err
+}
+
+// WriteMetrics writes the metrics of the sessions' scans and of the process
+// in the Prometheus text format. Counters are summed over the sessions and
+// the scans retired from m.
+func WriteMetrics(w io.Writer, m *Metrics, sessions []*Session) error {
+	totals := scanTotals{rules: make(map[ruleKey]int)}
+	if m != nil {
+		m.Lock()
+		totals = m.retired
+		totals.rules = make(map[ruleKey]int)
+		for key, count := range m.retired.rules {
+			totals.rules[key] = count
+		}
+		m.Unlock()
+	}
+	statuses := map[string]int{
+		StatusInitializing: 0,
+		StatusGathering:    0,
+		StatusAnalyzing:    0,
+		StatusFinished:     0,
+		StatusCancelled:    0,
+		StatusFailed:       0,
+	}
+	progress := make(map[string]float64)
+	for _, s := range sessions {
+		totals.add(s)
+		s.Stats.Lock()
+		statuses[s.Stats.Status]++
+		switch s.Stats.Status {
+		case StatusFinished, StatusCancelled, StatusFailed:
+		default:
+			progress[scanId(s)] = s.Stats.Progress
+		}
+		s.Stats.Unlock()
+	}
+
+	var ruleKeys []ruleKey
+	for key := range totals.rules {
+		ruleKeys = append(ruleKeys, key)
+	}
+	sort.Slice(ruleKeys, func(i, j int) bool {
+		if ruleKeys[i].rule != ruleKeys[j].rule {
+			return ruleKeys[i].rule < ruleKeys[j].rule
+		}
+		return ruleKeys[i].severity < ruleKeys[j].severity
+	})
+
+	e := &metricsEncoder{w: bufio.NewWriter(w)}
+	e.counter("gitrob_targets_total", "Users and organizations gathered.", totals.targets)
+	e.counter("gitrob_repositories_total", "Repositories analyzed.", totals.repositories)
+	e.counter("gitrob_commits_total", "Commits analyzed.", totals.commits)
+	e.counter("gitrob_files_total", "Files analyzed.", totals.files)
+	e.counter("gitrob_findings_total", "Findings found.", totals.findings)
+	e.counter("gitrob_errors_total", "Errors that left part of a scan incomplete.", totals.errors)
+	e.counter("gitrob_timed_out_repositories_total", "Repositories whose analysis exceeded -repo-timeout.", totals.timedOut)
+	e.counter("gitrob_skipped_files_total", "Files that were not scanned, or only partially scanned.", totals.skipped)
+
+	e.header("gitrob_rule_findings_total", "counter", "Findings by rule and severity.")
+	for _, key := range ruleKeys {
+		e.sample("gitrob_rule_findings_total", labels("rule", key.rule, "severity", key.severity), float64(totals.rules[key]))
+	}
+
+	e.header("gitrob_scans", "gauge", "Scans by phase.")
+	var phases []string
+	for status := range statuses {
+		phases = append(phases, status)
+	}
+	sort.Strings(phases)
+	for _, status := range phases {
+		e.sample("gitrob_scans", labels("phase", status), float64(statuses[status]))
+	}
+	e.header("gitrob_scan_progress", "gauge", "Percentage of the repositories of running scans that were analyzed.")
+	var ids []string
+	for id := range progress {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		e.sample("gitrob_scan_progress", labels("scan", id), progress[id])
+	}
+
+	if m != nil {
+		m.Lock()
+		e.header("gitrob_clone_duration_seconds", "histogram", "Time taken to clone repositories.")
+		for i, bound := range CloneDurationBuckets {
+			e.sample("gitrob_clone_duration_seconds_bucket", labels("le", formatFloat(bound)), float64(m.cloneBuckets[i]))
+		}
+		e.sample("gitrob_clone_duration_seconds_bucket", labels("le", "+Inf"), float64(m.cloneCount))
+		e.sample("gitrob_clone_duration_seconds_sum", "", m.cloneSeconds)
+		e.sample("gitrob_clone_duration_seconds_count", "", float64(m.cloneCount))
+		e.counter("gitrob_clone_errors_total", "Repositories that failed to clone.", m.cloneErrors)
+
+		if m.rateKnown {
+			e.gauge("gitrob_github_rate_limit", "Requests per hour allowed by the GitHub API.", float64(m.rateLimit))
+			e.gauge("gitrob_github_rate_limit_remaining", "Requests left in the current GitHub API rate limit window.", float64(m.rateRemaining))
+			e.gauge("gitrob_github_rate_limit_reset_timestamp_seconds", "Time the GitHub API rate limit window resets.", float64(m.rateReset.Unix()))
+		}
+		if m.pool != nil {
+			e.gauge("gitrob_workers", "Workers analyzing repositories.", float64(m.pool.Workers()))
+			e.gauge("gitrob_workers_busy", "Workers busy analyzing a repository.", float64(m.pool.Busy()))
+		}
+		m.Unlock()
+	}
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// MetricsHandler serves the metrics of the scans of the given sessions
+func MetricsHandler(m *Metrics, sessions func() []*Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", MetricsContentType)
+		c.Status(http.StatusOK)
+		WriteMetrics(c.Writer, m, sessions())
+	}
+}
+
+func scanId(s *Session) string {
+	if s.Scan == nil {
+		return ""
+	}
+	return s.Scan.Id
+}
+
+// metricsEncoder writes metrics in the Prometheus text format, keeping the
+// first error
+type metricsEncoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *metricsEncoder) header(name string, kind string, help string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (e *metricsEncoder) sample(name string, labels string, value float64) {
+	e.printf("%s%s %s\n", name, labels, formatFloat(value))
+}
+
+func (e *metricsEncoder) counter(name string, help string, value int) {
+	e.header(name, "counter", help)
+	e.sample(name, "", float64(value))
+}
+
+func (e *metricsEncoder) gauge(name string, help string, value float64) {
+	e.header(name, "gauge", help)
+	e.sample(name, "", value)
+}
+
+func (e *metricsEncoder) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+// labels formats pairs of label names and values
+func labels(pairs ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf("%s=\"%s\"", pairs[i], labelEscaper.Replace(pairs[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		pairs []string
+		want  string
+	}{
+		{[]string{"rule", "aws_key"}, `{rule="aws_key"}`},
+		{[]string{"rule", "a", "severity", "high"}, `{rule="a",severity="high"}`},
+		{[]string{"rule", `say "hi"`}, `{rule="say \"hi\""}`},
+		{[]string{"rule", `C:\keys`}, `{rule="C:\\keys"}`},
+		{[]string{"rule", "two\nlines"}, `{rule="two\nlines"}`},
+		{[]string{"rule", `\"` + "\n"}, `{rule="\\\"\n"}`},
+		{[]string{"rule"}, `{}`},
+	}
+	for _, test := range tests {
+		if got := labels(test.pairs...); got != test.want {
+			t.Errorf("%q: got %s, want %s", test.pairs, got, test.want)
+		}
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	session := func(status string, findings ...*Finding) *Session {
+		s := &Session{Scan: &ScanRun{Id: status}, Findings: findings}
+		s.InitStats()
+		s.Stats.Status = status
+		s.Stats.Findings = len(findings)
+		s.Stats.Progress = 50
+		return s
+	}
+	m := NewMetrics()
+	m.Retire(session(StatusFinished, &Finding{RuleId: "aws_key", Severity: "high"}))
+	sessions := []*Session{
+		session(StatusAnalyzing, &Finding{RuleId: "aws_key", Severity: "high"}, &Finding{RuleId: "evil\"}\nrule", Severity: "low"}),
+		session(StatusFinished),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMetrics(&buf, m, sessions); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"gitrob_findings_total 3\n",
+		`gitrob_rule_findings_total{rule="aws_key",severity="high"} 2` + "\n",
+		`gitrob_rule_findings_total{rule="evil\"}\nrule",severity="low"} 1` + "\n",
+		`gitrob_scans{phase="analyzing"} 1` + "\n",
+		`gitrob_scans{phase="finished"} 1` + "\n",
+		`gitrob_scan_progress{scan="analyzing"} 50` + "\n",
+		`gitrob_clone_duration_seconds_bucket{le="+Inf"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics don't contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `scan="finished"`) {
+		t.Errorf("progress of a finished scan is exported")
+	}
+	// Every sample must be on a line of its own
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if !strings.HasPrefix(line, "# ") && !strings.HasPrefix(line, "gitrob_") {
+			t.Errorf("line %q isn't a sample or comment", line)
+		}
+	}
+}
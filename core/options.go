@@ -3,51 +3,145 @@ package core
 import (
 	"flag"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 )
 
 type Options struct {
-	CommitDepth       *int
-	GithubAccessToken *string `json:"-"`
-	NoExpandOrgs      *bool
-	Threads           *int
-	Save              *string `json:"-"`
-	Load              *string `json:"-"`
-	BindAddress       *string
-	Port              *int
-	Silent            *bool
-	Debug             *bool
-	NoWebServer       *bool // Flag to disable web server
-	Logins            []string
-	RepoURL           *string // Single repository URL to scan
-	RepoListFile      *string // Path to file containing list of repositories
-	ConfigPath        *string // Path to config.yaml file
+	CommitDepth        *int
+	GithubAccessToken  *string `json:"-"`
+	NoExpandOrgs       *bool
+	Threads            *int
+	Save               *string        `json:"-"`
+	Format             *string        `json:"-"`
+	Output             *string        `json:"-"` // File to append findings to as JSON Lines, or - for stdout
+	Load               *string        `json:"-"`
+	Checkpoint         *string        `json:"-"` // File to periodically write the scan state to
+	CheckpointInterval *time.Duration `json:"-"`
+	Resume             *string        `json:"-"` // Checkpoint to resume a scan from
+	Database           *string        `json:"-"` // Embedded database to store scan runs in
+	KeyFile            *string        `json:"-"` // age key file to encrypt session, checkpoint and report files with
+	TriageFrom         *string        `json:"-"` // Session file to carry the triage of findings forward from
+	SaveTriage         *bool          `json:"-"` // Save triage to the session file given with -load
+	CloneCache         *string        `json:"-"` // Directory to keep clones of scanned repositories in
+	BindAddress        *string
+	Port               *int
+	TLSCert            *string `json:"-"` // Certificate to serve the web interface over HTTPS with
+	TLSKey             *string `json:"-"`
+	TLSSelfSigned      *bool   `json:"-"` // Serve the web interface over HTTPS with a generated certificate
+	Htpasswd           *string `json:"-"` // htpasswd file with users allowed to sign in to the web interface
+	Silent             *bool
+	Debug              *bool
+	NoWebServer        *bool // Flag to disable web server
+	Logins             []string
+	RepoURL            *string // Single repository URL to scan
+	RepoListFile       *string // Path to file containing list of repositories
+	ConfigPath         *string // Path to config.yaml file
+	ArchiveDepth       *int    // Maximum nesting depth when scanning inside archives
+	ArchiveMaxSize     *int64  // Maximum total uncompressed size extracted from an archive
+	Decode             *bool   // Decode base64, hex and URL-encoded values before content matching
+	DecodeMinLength    *int    // Minimum length of an encoded value to be decoded
+	MaxFileSize        *int64
+	MaxPreviewSize     *int64         // Maximum size of files shown in the web interface
+	RepoTimeout        *time.Duration // Maximum wall-clock time spent on a single repository
+	RegexTimeout       *time.Duration // Maximum time spent evaluating a content signature on a single file
+	Verify             *bool          // Check whether matched secrets are live with the signature's verifier
+	VerifyThreads      *int
+	FailOn             *string // Minimum severity of findings that fail the scan
+	MaxFindings        *int    // Number of findings tolerated before the scan fails
 }
 
+// ParseOptions parses the command line flags
 func ParseOptions() (Options, error) {
+	return ParseOptionsFrom(flag.CommandLine, os.Args[1:])
+}
+
+// ParseOptionsFrom defines the options on flags and parses them from args.
+// Arguments after the flags are the logins of targets.
+func ParseOptionsFrom(flags *flag.FlagSet, args []string) (Options, error) {
 	options := Options{
-		CommitDepth:       flag.Int("commit-depth", 500, "Number of repository commits to process"),
-		GithubAccessToken: flag.String("github-access-token", "", "GitHub access token to use for API requests"),
-		NoExpandOrgs:      flag.Bool("no-expand-orgs", false, "Don't add members to targets when processing organizations"),
-		Threads:           flag.Int("threads", 0, "Number of concurrent threads (default number of logical CPUs)"),
-		Save:              flag.String("save", "", "Save session file"),
-		Load:              flag.String("load", "", "Load session file"),
-		BindAddress:       flag.String("bind-address", "127.0.0.1", "Address to bind web server to"),
-		Port:              flag.Int("port", 9393, "Port to run web server on"),
-		Silent:            flag.Bool("silent", false, "Suppress all output except for errors"),
-		Debug:             flag.Bool("debug", false, "Print debugging information"),
-		NoWebServer:       flag.Bool("no-web", false, "Disable web interface"),
-		RepoURL:           flag.String("repo", "", "Single GitHub repository URL to scan (e.g. 'owner/repo')"),
-		RepoListFile:      flag.String("repo-list", "", "Path to file containing list of repositories (one per line in owner/repo format)"),
-		ConfigPath:        flag.String("config", "", "Path to config.yaml file (required)"),
-	}
-
-	flag.Parse()
-	options.Logins = flag.Args()
+		CommitDepth:        flags.Int("commit-depth", 500, "Number of repository commits to process"),
+		GithubAccessToken:  flags.String("github-access-token", "", "GitHub access token to use for API requests"),
+		NoExpandOrgs:       flags.Bool("no-expand-orgs", false, "Don't add members to targets when processing organizations"),
+		Threads:            flags.Int("threads", 0, "Number of concurrent threads (default number of logical CPUs)"),
+		Save:               flags.String("save", "", "Save session file"),
+		Output:             flags.String("output", "", "Append each finding to this file as a line of JSON as soon as it is found (- for stdout)"),
+		Format:             flags.String("format", ReportFormatJSON, "Format of the file written with -save: json (session file that can be loaded), sarif, junit, csv, markdown or html"),
+		Load:               flags.String("load", "", "Load session file"),
+		Checkpoint:         flags.String("checkpoint", "", "Periodically save the state of the scan to this file, so it can be resumed with -resume"),
+		CheckpointInterval: flags.Duration("checkpoint-interval", DefaultCheckpointInterval, "Time between checkpoints"),
+		Database:           flags.String("db", "", "Store targets, repositories and findings of scans in this database file, and serve them from it"),
+		KeyFile:            flags.String("key", "", "Encrypt session, checkpoint and report files with the X25519 identities in this age key file, and decrypt loaded files with them (or set "+PassphraseEnvVariable+" to use a passphrase)"),
+		TriageFrom:         flags.String("triage-from", "", "Copy the triage status, assignee and note of findings in this earlier session file to the same findings in this scan"),
+		SaveTriage:         flags.Bool("save-triage", false, "Save triage made in the web interface to the session file given with -load, replacing it"),
+		CloneCache:         flags.String("clone-cache", "", "Keep bare clones of scanned repositories in this directory, to show file contents in the web interface from"),
+		Resume:             flags.String("resume", "", "Resume the scan saved in this checkpoint file, skipping repositories already analyzed"),
+		BindAddress:        flags.String("bind-address", "127.0.0.1", "Address to bind web server to"),
+		Port:               flags.Int("port", 9393, "Port to run web server on"),
+		TLSCert:            flags.String("tls-cert", "", "Serve the web interface over HTTPS with this PEM certificate (requires -tls-key)"),
+		TLSKey:             flags.String("tls-key", "", "PEM private key of the -tls-cert certificate"),
+		TLSSelfSigned:      flags.Bool("tls-self-signed", false, "Serve the web interface over HTTPS with a generated self-signed certificate"),
+		Htpasswd:           flags.String("htpasswd", "", "Require users of the web interface to sign in with a user of this htpasswd file (bcrypt or SHA1 hashes)"),
+		Silent:             flags.Bool("silent", false, "Suppress all output except for errors"),
+		Debug:              flags.Bool("debug", false, "Print debugging information"),
+		NoWebServer:        flags.Bool("no-web", false, "Disable web interface"),
+		RepoURL:            flags.String("repo", "", "Single GitHub repository URL to scan (e.g. 'owner/repo')"),
+		RepoListFile:       flags.String("repo-list", "", "Path to file containing list of repositories (one per line in owner/repo format)"),
+		ConfigPath:         flags.String("config", "", "Path to config.yaml file (required)"),
+		ArchiveDepth:       flags.Int("archive-depth", DefaultArchiveDepth, "Maximum nesting depth when scanning inside archives (0 disables archive scanning)"),
+		ArchiveMaxSize:     flags.Int64("archive-max-size", DefaultArchiveMaxSize, "Maximum number of bytes to extract from a single archive"),
+		Decode:             flags.Bool("decode", false, "Decode base64, hex and URL-encoded values and match content signatures against them"),
+		DecodeMinLength:    flags.Int("decode-min-length", DefaultDecodeMinLength, "Minimum length of encoded values to decode"),
+		MaxFileSize:        flags.Int64("max-file-size", DefaultMaxFileSize, "Maximum size in bytes of files to scan"),
+		MaxPreviewSize:     flags.Int64("max-preview-size", DefaultMaxPreviewSize, "Maximum size in bytes of files shown in the web interface"),
+		RepoTimeout:        flags.Duration("repo-timeout", 0, "Maximum time to spend analyzing a single repository, e.g. 30m (0 for no limit)"),
+		Verify:             flags.Bool("verify", false, "Check whether matched secrets are live using the verifiers referenced by signatures (sends secrets to the issuing services)"),
+		VerifyThreads:      flags.Int("verify-threads", DefaultVerifyThreads, "Number of concurrent secret verifications"),
+		FailOn:             flags.String("fail-on", "", "Exit with code 1 if there are findings of this severity or higher: "+strings.Join(Severities, ", ")),
+		MaxFindings:        flags.Int("max-findings", -1, "Exit with code 1 if there are more than this many findings (of the -fail-on severity or higher, if set)"),
+		RegexTimeout:       flags.Duration("regex-timeout", 0, "Maximum time to spend evaluating a content signature on a single file, e.g. 5s (0 for no limit)"),
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+	options.Logins = flags.Args()
 
 	// Validate required config file path
 	if *options.ConfigPath == "" {
 		return options, fmt.Errorf("config file path is required. Use -config flag to specify the path to config.yaml")
 	}
 
+	if _, ok := ReportWriters[*options.Format]; !ok {
+		return options, fmt.Errorf("unknown format %q. Use one of: %s", *options.Format, strings.Join(ReportFormats(), ", "))
+	}
+
+	if *options.Load != "" && *options.Resume != "" {
+		return options, fmt.Errorf("-load and -resume can't be used together")
+	}
+	if *options.CheckpointInterval <= 0 {
+		return options, fmt.Errorf("-checkpoint-interval must be greater than 0")
+	}
+	// Findings are streamed as they're found, which age can't encrypt
+	if *options.Output != "" && (*options.KeyFile != "" || os.Getenv(PassphraseEnvVariable) != "") {
+		return options, fmt.Errorf("-output writes findings unencrypted and can't be used with -key or %s. Save an encrypted session with -save instead", PassphraseEnvVariable)
+	}
+	if *options.VerifyThreads < 1 {
+		return options, fmt.Errorf("-verify-threads must be at least 1")
+	}
+
+	if (*options.TLSCert == "") != (*options.TLSKey == "") {
+		return options, fmt.Errorf("-tls-cert and -tls-key must be used together")
+	}
+	if *options.TLSCert != "" && *options.TLSSelfSigned {
+		return options, fmt.Errorf("-tls-cert and -tls-self-signed can't be used together")
+	}
+
+	*options.FailOn = strings.ToLower(*options.FailOn)
+	if *options.FailOn != "" && SeverityRank(*options.FailOn) == -1 {
+		return options, fmt.Errorf("unknown severity %q for -fail-on. Use one of: %s", *options.FailOn, strings.Join(Severities, ", "))
+	}
+
 	return options, nil
 }
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// AnalyzeFunc analyzes a repository of a session on the pool worker tid
+type AnalyzeFunc func(s *Session, tid int, repository *GithubRepository)
+
+// RepositoryPool analyzes the repositories of any number of sessions with a
+// fixed number of workers, so concurrent scans share the same threads.
+type RepositoryPool struct {
+	analyze AnalyzeFunc
+	jobs    chan repositoryJob
+	workers int
+	busy    int32
+	wg      sync.WaitGroup
+}
+
+type repositoryJob struct {
+	session    *Session
+	repository *GithubRepository
+	done       *sync.WaitGroup
+}
+
+// NewRepositoryPool starts workers that analyze repositories with analyze
+func NewRepositoryPool(workers int, analyze AnalyzeFunc) *RepositoryPool {
+	p := &RepositoryPool{
+		analyze: analyze,
+		jobs:    make(chan repositoryJob),
+		workers: workers,
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work(i)
+	}
+	return p
+}
+
+func (p *RepositoryPool) work(tid int) {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		s := job.session
+		if !s.IsCancelled() {
+			atomic.AddInt32(&p.busy, 1)
+			p.analyze(s, tid, job.repository)
+			atomic.AddInt32(&p.busy, -1)
+			// Repositories left by a cancelled scan aren't complete
+			if !s.IsCancelled() {
+				s.CompleteRepository(job.repository)
+				s.Stats.IncrementRepositories()
+				s.Stats.UpdateProgress(s.Stats.Repositories, len(s.Repositories))
+			}
+		}
+		job.done.Done()
+	}
+}
+
+// Analyze queues the repositories of a session and waits until they are
+// analyzed, or skipped because the session's scan was cancelled
+func (p *RepositoryPool) Analyze(s *Session, repositories []*GithubRepository) {
+	var done sync.WaitGroup
+	for _, repository := range repositories {
+		done.Add(1)
+		select {
+		case p.jobs <- repositoryJob{session: s, repository: repository, done: &done}:
+		case <-s.Context().Done():
+			done.Done()
+		}
+	}
+	done.Wait()
+}
+
+// Close stops the workers once they're done with the queued repositories
+func (p *RepositoryPool) Close() {
+	close(p.jobs)
+	p.wg.Wait()
+}
+
+// Workers returns the number of workers in the pool
+func (p *RepositoryPool) Workers() int {
+	return p.workers
+}
+
+// Busy returns the number of workers analyzing a repository
+func (p *RepositoryPool) Busy() int {
+	return int(atomic.LoadInt32(&p.busy))
+}
@@ -0,0 +1,145 @@
+package core
+
+// Keyworded is implemented by signatures that declare literal keywords, at
+// least one of which must appear in the content for the signature to match.
+type Keyworded interface {
+	Keywords() []string
+}
+
+// Prefilter decides which content signatures are worth evaluating for a
+// file by searching for all signature keywords in a single pass.
+type Prefilter struct {
+	automaton        *AhoCorasick
+	keywordSignature []int
+	keyworded        []bool
+}
+
+// AhoCorasick is a case insensitive multi-pattern string matcher
+type AhoCorasick struct {
+	transitions [][256]int32
+	outputs     [][]int
+}
+
+var asciiLower [256]byte
+
+func init() {
+	for i := range asciiLower {
+		c := byte(i)
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		asciiLower[i] = c
+	}
+}
+
+// NewPrefilter builds a prefilter over the keywords of all signatures.
+// Signatures without keywords are always considered candidates.
+func NewPrefilter(signatures []Signature) *Prefilter {
+	p := &Prefilter{
+		keyworded: make([]bool, len(signatures)),
+	}
+	var keywords []string
+	for i, signature := range signatures {
+		k, ok := signature.(Keyworded)
+		if !ok {
+			continue
+		}
+		for _, keyword := range k.Keywords() {
+			if keyword == "" {
+				continue
+			}
+			keywords = append(keywords, keyword)
+			p.keywordSignature = append(p.keywordSignature, i)
+			p.keyworded[i] = true
+		}
+	}
+	p.automaton = NewAhoCorasick(keywords)
+	return p
+}
+
+// Candidates returns, for every signature the prefilter was built from,
+// whether it may match the given content.
+func (p *Prefilter) Candidates(contents ...[]byte) []bool {
+	candidates := make([]bool, len(p.keyworded))
+	for i, keyworded := range p.keyworded {
+		candidates[i] = !keyworded
+	}
+	found := make([]bool, len(p.keywordSignature))
+	for _, content := range contents {
+		p.automaton.MatchSet(content, found)
+	}
+	for i, ok := range found {
+		if ok {
+			candidates[p.keywordSignature[i]] = true
+		}
+	}
+	return candidates
+}
+
+// NewAhoCorasick builds an automaton matching all patterns, ignoring ASCII case
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	a := &AhoCorasick{
+		transitions: make([][256]int32, 1),
+		outputs:     make([][]int, 1),
+	}
+
+	// Build the trie, using -1 for missing transitions
+	for i := range a.transitions[0] {
+		a.transitions[0][i] = -1
+	}
+	for i, pattern := range patterns {
+		state := int32(0)
+		for j := 0; j < len(pattern); j++ {
+			c := asciiLower[pattern[j]]
+			if a.transitions[state][c] == -1 {
+				var node [256]int32
+				for k := range node {
+					node[k] = -1
+				}
+				a.transitions = append(a.transitions, node)
+				a.outputs = append(a.outputs, nil)
+				a.transitions[state][c] = int32(len(a.transitions) - 1)
+			}
+			state = a.transitions[state][c]
+		}
+		a.outputs[state] = append(a.outputs[state], i)
+	}
+
+	// Turn the trie into a complete automaton by following failure links
+	fail := make([]int32, len(a.transitions))
+	var queue []int32
+	for c := range a.transitions[0] {
+		if next := a.transitions[0][c]; next == -1 {
+			a.transitions[0][c] = 0
+		} else {
+			fail[next] = 0
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		a.outputs[state] = append(a.outputs[state], a.outputs[fail[state]]...)
+		for c := range a.transitions[state] {
+			next := a.transitions[state][c]
+			if next == -1 {
+				a.transitions[state][c] = a.transitions[fail[state]][c]
+				continue
+			}
+			fail[next] = a.transitions[fail[state]][c]
+			queue = append(queue, next)
+		}
+	}
+	return a
+}
+
+// MatchSet marks the index of every pattern found in haystack in found
+func (a *AhoCorasick) MatchSet(haystack []byte, found []bool) {
+	state := int32(0)
+	for _, c := range haystack {
+		state = a.transitions[state][asciiLower[c]]
+		for _, i := range a.outputs[state] {
+			found[i] = true
+		}
+	}
+}
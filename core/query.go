@@ -0,0 +1,346 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultFindingsLimit = 100
+	MaxFindingsLimit     = 1000
+)
+
+// FindingsSorts maps the values of the sort query parameter to functions
+// reporting whether finding a sorts before finding b
+var FindingsSorts = map[string]func(a, b *Finding) bool{
+	"action":     func(a, b *Finding) bool { return a.Action < b.Action },
+	"author":     func(a, b *Finding) bool { return lessFold(a.CommitAuthor, b.CommitAuthor) },
+	"date":       func(a, b *Finding) bool { return a.CommitDate.Before(b.CommitDate) },
+	"owner":      func(a, b *Finding) bool { return lessFold(a.RepositoryOwner, b.RepositoryOwner) },
+	"path":       func(a, b *Finding) bool { return a.FilePath < b.FilePath },
+	"repository": func(a, b *Finding) bool { return lessFold(findingRepository(a), findingRepository(b)) },
+	"rule":       func(a, b *Finding) bool { return a.RuleId < b.RuleId },
+	"severity":   func(a, b *Finding) bool { return findingSeverityRank(a) < findingSeverityRank(b) },
+}
+
+// FindingsQuery filters, sorts and paginates findings. Filters with several
+// values match findings that match any of them.
+type FindingsQuery struct {
+	Search       string // Matched against the path, commit hash and repository
+	Repositories []string
+	Owners       []string
+	RuleIds      []string
+	Severities   []string
+	Actions      []string
+	Authors      []string // Matched against part of the commit author
+	Paths        []*regexp.Regexp
+	Triage       []string
+	Since        time.Time
+	Until        time.Time
+	Sort         string
+	Descending   bool
+	Offset       int
+	Limit        int
+}
+
+// FindingsPage is a page of findings matching a FindingsQuery
+type FindingsPage struct {
+	Findings   []*Finding
+	Total      int    // Findings before filtering
+	Matching   int    // Findings matching the filters
+	NextCursor string // Cursor of the page after this one
+}
+
+// ParseFindingsQuery parses the query parameters of a findings request:
+//
+//	cursor      cursor of the page to return, from NextCursor
+//	limit       findings per page, up to MaxFindingsLimit
+//	q           text in the path, commit hash or repository
+//	repository  repository name or owner/name
+//	owner, rule, severity, action
+//	author      part of the commit author's name or email address
+//	path        glob of the file path, where ** matches any directories
+//	triage      open, false_positive, accepted or fixed
+//	since, until  commit date as RFC 3339 or YYYY-MM-DD
+//	sort        action, author, date, owner, path, repository, rule or
+//	            severity, prefixed with - to sort in descending order
+//
+// Filters can be repeated or given as a comma separated list.
+func ParseFindingsQuery(values url.Values) (*FindingsQuery, error) {
+	q := &FindingsQuery{
+		Search:       strings.ToLower(strings.TrimSpace(values.Get("q"))),
+		Repositories: queryValues(values, "repository"),
+		Owners:       queryValues(values, "owner"),
+		RuleIds:      queryValues(values, "rule"),
+		Severities:   queryValues(values, "severity"),
+		Actions:      queryValues(values, "action"),
+		Authors:      queryValues(values, "author"),
+		Triage:       queryValues(values, "triage"),
+		Limit:        DefaultFindingsLimit,
+	}
+
+	for _, glob := range queryValues(values, "path") {
+		q.Paths = append(q.Paths, GlobRegexp(glob))
+	}
+	for i, status := range q.Triage {
+		status = strings.ToLower(status)
+		if !isTriageStatus(status) {
+			return nil, fmt.Errorf("unknown triage status %q, must be one of: %s", status, strings.Join(TriageStatuses, ", "))
+		}
+		q.Triage[i] = status
+	}
+
+	var err error
+	if q.Since, err = parseQueryDate(values.Get("since"), false); err != nil {
+		return nil, fmt.Errorf("invalid since: %v", err)
+	}
+	if q.Until, err = parseQueryDate(values.Get("until"), true); err != nil {
+		return nil, fmt.Errorf("invalid until: %v", err)
+	}
+
+	if sortBy := values.Get("sort"); sortBy != "" {
+		q.Descending = strings.HasPrefix(sortBy, "-")
+		q.Sort = strings.TrimPrefix(sortBy, "-")
+		if _, ok := FindingsSorts[q.Sort]; !ok {
+			return nil, fmt.Errorf("unknown sort %q", q.Sort)
+		}
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit < 1 || q.Limit > MaxFindingsLimit {
+			return nil, fmt.Errorf("limit must be a number from 1 to %d", MaxFindingsLimit)
+		}
+	}
+	if cursor := values.Get("cursor"); cursor != "" {
+		if q.Offset, err = decodeCursor(cursor); err != nil {
+			return nil, fmt.Errorf("invalid cursor %q", cursor)
+		}
+	}
+	return q, nil
+}
+
+// Apply returns the page of the findings that match the query
+func (q *FindingsQuery) Apply(findings []*Finding) *FindingsPage {
+	var matching []*Finding
+	for _, finding := range findings {
+		if q.Matches(finding) {
+			matching = append(matching, finding)
+		}
+	}
+	return q.page(matching, len(findings))
+}
+
+// page sorts the findings matching the query and returns the page of them
+// the cursor points to
+func (q *FindingsQuery) page(matching []*Finding, total int) *FindingsPage {
+	page := &FindingsPage{Findings: []*Finding{}, Total: total}
+	if q.Sort != "" {
+		less := FindingsSorts[q.Sort]
+		sort.SliceStable(matching, func(i, j int) bool {
+			if q.Descending {
+				return less(matching[j], matching[i])
+			}
+			return less(matching[i], matching[j])
+		})
+	}
+	page.Matching = len(matching)
+
+	// The cursor of the last page points past its end, so clients can keep
+	// using it to fetch findings that are made later
+	start := q.Offset
+	if start > len(matching) {
+		start = len(matching)
+	}
+	end := start + q.Limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	page.Findings = append(page.Findings, matching[start:end]...)
+	page.NextCursor = encodeCursor(end)
+	return page
+}
+
+// Unfiltered reports whether the query matches all findings in the order
+// they were made, so a page can be read without looking at other findings
+func (q *FindingsQuery) Unfiltered() bool {
+	return q.Search == "" && len(q.Repositories) == 0 && len(q.Owners) == 0 && len(q.RuleIds) == 0 &&
+		len(q.Severities) == 0 && len(q.Actions) == 0 && len(q.Authors) == 0 && len(q.Paths) == 0 &&
+		len(q.Triage) == 0 && q.Since.IsZero() && q.Until.IsZero() && q.Sort == ""
+}
+
+// QueryFindings returns the page of the session's findings that match the
+// query. The page holds copies of the findings, so it can be encoded after
+// the session is unlocked while triage and verification update them.
+func (s *Session) QueryFindings(q *FindingsQuery) *FindingsPage {
+	s.Lock()
+	defer s.Unlock()
+	page := q.Apply(s.Findings)
+	for i, finding := range page.Findings {
+		f := *finding
+		page.Findings[i] = &f
+	}
+	return page
+}
+
+// Matches reports whether the finding matches all filters of the query
+func (q *FindingsQuery) Matches(f *Finding) bool {
+	repository := findingRepository(f)
+	if q.Search != "" &&
+		!strings.Contains(strings.ToLower(f.FilePath), q.Search) &&
+		!strings.Contains(strings.ToLower(f.CommitHash), q.Search) &&
+		!strings.Contains(strings.ToLower(repository), q.Search) {
+		return false
+	}
+	if len(q.Repositories) > 0 && !matchesAny(q.Repositories, func(r string) bool {
+		return strings.EqualFold(r, repository) || strings.EqualFold(r, f.RepositoryName)
+	}) {
+		return false
+	}
+	if !matchesFold(q.Owners, f.RepositoryOwner) ||
+		!matchesFold(q.RuleIds, f.RuleId) ||
+		!matchesFold(q.Severities, f.Severity) ||
+		!matchesFold(q.Actions, f.Action) {
+		return false
+	}
+	if len(q.Authors) > 0 && !matchesAny(q.Authors, func(a string) bool {
+		return strings.Contains(strings.ToLower(f.CommitAuthor), strings.ToLower(a))
+	}) {
+		return false
+	}
+	if len(q.Paths) > 0 {
+		matched := false
+		for _, path := range q.Paths {
+			if path.MatchString(f.FilePath) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(q.Triage) > 0 && !matchesAny(q.Triage, func(t string) bool {
+		return t == f.TriageStatus || (t == TriageOpen && !f.IsTriaged())
+	}) {
+		return false
+	}
+	if !q.Since.IsZero() && (f.CommitDate.IsZero() || f.CommitDate.Before(q.Since)) {
+		return false
+	}
+	if !q.Until.IsZero() && (f.CommitDate.IsZero() || f.CommitDate.After(q.Until)) {
+		return false
+	}
+	return true
+}
+
+// GlobRegexp compiles a glob of a path to a regular expression. * and ?
+// match within a directory, and ** matches any number of directories.
+func GlobRegexp(glob string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+func findingRepository(f *Finding) string {
+	return f.RepositoryOwner + "/" + f.RepositoryName
+}
+
+func findingSeverityRank(f *Finding) int {
+	rank := SeverityRank(f.Severity)
+	if rank == -1 {
+		rank = SeverityRank(DefaultSeverity)
+	}
+	return rank
+}
+
+// queryValues returns the values of a query parameter, splitting comma
+// separated lists
+func queryValues(values url.Values, key string) []string {
+	var result []string
+	for _, value := range values[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+// parseQueryDate parses an RFC 3339 timestamp or a date. A date is the start
+// of the day, or the end of the day for the end of a date range.
+func parseQueryDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date or RFC 3339 timestamp", value)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+func lessFold(a, b string) bool {
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+func matchesAny(values []string, fn func(value string) bool) bool {
+	for _, value := range values {
+		if fn(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFold(values []string, s string) bool {
+	return len(values) == 0 || matchesAny(values, func(value string) bool {
+		return strings.EqualFold(value, s)
+	})
+}
+
+// Cursors are opaque to clients, so pagination can change how they work
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset")
+	}
+	return offset, nil
+}
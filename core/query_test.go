@@ -0,0 +1,134 @@
+package core
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGlobRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{"*.env", ".env", true},
+		{"*.env", "prod.env", true},
+		{"*.env", "config/prod.env", false},
+		{"**/*.env", "prod.env", true},
+		{"**/*.env", "config/prod.env", true},
+		{"**/*.env", "a/b/c/prod.env", true},
+		{"config/**", "config/a/b.yml", true},
+		{"config/**", "other/config/a.yml", false},
+		{"src/**/secrets.yml", "src/secrets.yml", true},
+		{"src/**/secrets.yml", "src/main/resources/secrets.yml", true},
+		{"id_rs?", "id_rsa", true},
+		{"id_rs?", "id_rs/a", false},
+		{"a.b", "axb", false},
+		{"(a)+[b]", "(a)+[b]", true},
+		{"app.war!/**", "app.war!/WEB-INF/web.xml", true},
+		{"*", "", true},
+	}
+	for _, test := range tests {
+		if matches := GlobRegexp(test.glob).MatchString(test.path); matches != test.matches {
+			t.Errorf("%q against %q: got %v, want %v", test.glob, test.path, matches, test.matches)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	for _, offset := range []int{0, 1, 100, 123456} {
+		got, err := decodeCursor(encodeCursor(offset))
+		if err != nil || got != offset {
+			t.Errorf("%d: got %d, %v", offset, got, err)
+		}
+	}
+
+	invalid := []string{
+		"!!!",
+		base64.RawURLEncoding.EncodeToString([]byte("-1")),
+		base64.RawURLEncoding.EncodeToString([]byte("ten")),
+		base64.RawURLEncoding.EncodeToString([]byte("99999999999999999999999")),
+		base64.StdEncoding.EncodeToString([]byte("10")),
+	}
+	for _, cursor := range invalid {
+		if offset, err := decodeCursor(cursor); err == nil {
+			t.Errorf("%q: got offset %d, want an error", cursor, offset)
+		}
+	}
+}
+
+func TestParseFindingsQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{query: "q=env&repository=acme/api,web&severity=high&severity=critical&path=**/*.env&sort=-date"},
+		{query: "since=2024-01-01&until=2024-01-31T12:00:00Z"},
+		{query: "triage=Open,false_positive"},
+		{query: "triage=ignored", err: "unknown triage status"},
+		{query: "since=yesterday", err: "invalid since"},
+		{query: "until=2024-13-01", err: "invalid until"},
+		{query: "sort=-password", err: "unknown sort"},
+		{query: "limit=0", err: "limit must be"},
+		{query: "limit=1001", err: "limit must be"},
+		{query: "limit=ten", err: "limit must be"},
+		{query: "cursor=" + base64.RawURLEncoding.EncodeToString([]byte("-5")), err: "invalid cursor"},
+	}
+	for _, test := range tests {
+		values, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseFindingsQuery(values)
+		if test.err == "" && err != nil {
+			t.Errorf("%s: %v", test.query, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: got error %v, want %q", test.query, err, test.err)
+		}
+	}
+}
+
+func TestFindingsQueryPages(t *testing.T) {
+	var findings []*Finding
+	for _, path := range []string{"a/.env", "b.env", "c/d/.env", "config.yml", "e.env"} {
+		findings = append(findings, &Finding{FilePath: path, RepositoryOwner: "acme", RepositoryName: "api"})
+	}
+	values := url.Values{"path": {"**/*.env"}, "sort": {"-path"}, "limit": {"2"}}
+
+	var paths []string
+	for pages := 0; pages < 10; pages++ {
+		q, err := ParseFindingsQuery(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := q.Apply(findings)
+		if page.Total != 5 || page.Matching != 4 {
+			t.Fatalf("got %d total and %d matching, want 5 and 4", page.Total, page.Matching)
+		}
+		if len(page.Findings) == 0 {
+			break
+		}
+		for _, finding := range page.Findings {
+			paths = append(paths, finding.FilePath)
+		}
+		values.Set("cursor", page.NextCursor)
+	}
+	if got, want := strings.Join(paths, " "), "e.env c/d/.env b.env a/.env"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestQueryFindingsCopiesPage(t *testing.T) {
+	s := &Session{Findings: []*Finding{{Id: "a", FilePath: ".env"}}}
+	q, err := ParseFindingsQuery(url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := s.QueryFindings(q)
+	s.Findings[0].TriageStatus = TriageAccepted
+	if page.Findings[0] == s.Findings[0] || page.Findings[0].TriageStatus != "" {
+		t.Errorf("page shares findings with the session")
+	}
+}
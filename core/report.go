@@ -0,0 +1,107 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	ReportFormatJSON     = "json"
+	ReportFormatSARIF    = "sarif"
+	ReportFormatCSV      = "csv"
+	ReportFormatMarkdown = "markdown"
+	ReportFormatHTML     = "html"
+	ReportFormatJUnit    = "junit"
+)
+
+// ReportWriter writes the findings of a session in a report format
+type ReportWriter func(w io.Writer, s *Session) error
+
+var ReportWriters = map[string]ReportWriter{
+	ReportFormatJSON:     writeJSONReport,
+	ReportFormatSARIF:    WriteSARIFReport,
+	ReportFormatCSV:      WriteCSVReport,
+	ReportFormatMarkdown: WriteMarkdownReport,
+	ReportFormatHTML:     WriteHTMLReport,
+	ReportFormatJUnit:    WriteJUnitReport,
+}
+
+// ReportFormats returns the names of all supported report formats
+func ReportFormats() []string {
+	var formats []string
+	for format := range ReportWriters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// SaveReport writes the session to location in the given report format.
+// The json format is the session file, which can be loaded again with -load.
+func (s *Session) SaveReport(location string, format string) error {
+	writer, ok := ReportWriters[format]
+	if !ok {
+		return fmt.Errorf("unknown report format %q, must be one of: %s", format, strings.Join(ReportFormats(), ", "))
+	}
+	f, err := CreateFile(location, s.Key)
+	if err != nil {
+		return err
+	}
+	if err := writer(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSONReport(w io.Writer, s *Session) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReportRule describes a signature in reports
+type ReportRule struct {
+	Id          string
+	Type        string
+	Severity    string
+	Description string
+	Comment     string
+}
+
+// ReportRules returns the rules of all patterns in config, followed by any
+// rules only referenced by findings, e.g. from a session generated with a
+// different config file.
+func ReportRules(config *Config, findings []*Finding) []ReportRule {
+	var rules []ReportRule
+	seen := make(map[string]bool)
+	if config != nil {
+		for _, pattern := range config.Patterns {
+			if pattern.Name == "" || seen[pattern.Name] {
+				continue
+			}
+			seen[pattern.Name] = true
+			rules = append(rules, ReportRule{
+				Id:          pattern.Name,
+				Type:        pattern.Type,
+				Severity:    pattern.Severity,
+				Description: pattern.Description,
+				Comment:     pattern.Comment,
+			})
+		}
+	}
+	for _, finding := range findings {
+		if finding.RuleId == "" || seen[finding.RuleId] {
+			continue
+		}
+		seen[finding.RuleId] = true
+		rules = append(rules, ReportRule{
+			Id:          finding.RuleId,
+			Severity:    finding.Severity,
+			Description: finding.Description,
+			Comment:     finding.Comment,
+		})
+	}
+	return rules
+}
@@ -1,10 +1,12 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/gin-contrib/secure"
@@ -13,10 +15,8 @@ import (
 )
 
 const (
-	GithubBaseUri   = "https://raw.githubusercontent.com"
-	MaximumFileSize = 102400
-	CspPolicy       = "default-src 'none'; script-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; font-src 'self'"
-	ReferrerPolicy  = "no-referrer"
+	CspPolicy      = "default-src 'none'; script-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; font-src 'self'"
+	ReferrerPolicy = "no-referrer"
 )
 
 type binaryFileSystem struct {
@@ -50,6 +50,60 @@ func BinaryFileSystem(root string) *binaryFileSystem {
 }
 
 func NewRouter(s *Session) *gin.Engine {
+	router := newEngine(s)
+	router.Use(static.Serve("/", BinaryFileSystem("static")))
+	router.Use(secureHeaders())
+	router.GET("/metrics", MetricsHandler(s.Metrics, func() []*Session {
+		return []*Session{s}
+	}))
+	router.GET("/stats", func(c *gin.Context) {
+		// Stats of the session's own scan are only stored when it finishes
+		if scan, ok := storedScanId(c, s); ok && c.Query("scan") != "" {
+			stored, err := s.Store.Scan(scan)
+			if err != nil {
+				respondFromStore(c, nil, err)
+				return
+			}
+			c.JSON(200, stored.Stats)
+			return
+		}
+		c.JSON(200, s.Stats)
+	})
+	router.GET("/findings", listFindings(s))
+	router.PATCH("/findings/:id", triageFinding(s))
+	router.GET("/targets", func(c *gin.Context) {
+		if scan, ok := storedScanId(c, s); ok {
+			targets, err := s.Store.Owners(scan)
+			respondFromStore(c, targets, err)
+			return
+		}
+		c.JSON(200, s.Targets)
+	})
+	router.GET("/repositories", func(c *gin.Context) {
+		if scan, ok := storedScanId(c, s); ok {
+			repositories, err := s.Store.Repositories(scan)
+			respondFromStore(c, repositories, err)
+			return
+		}
+		c.JSON(200, s.Repositories)
+	})
+	router.GET("/scans", func(c *gin.Context) {
+		if s.Store == nil {
+			c.JSON(200, []*StoredScan{})
+			return
+		}
+		scans, err := s.Store.Scans()
+		respondFromStore(c, scans, err)
+	})
+	router.GET("/events", streamEvents(s))
+	router.GET("/files/:owner/:repo/:commit/*path", fetchFile(s))
+
+	return router
+}
+
+// newEngine returns a router that requires the session's credentials, if
+// any, and protects mutating requests against CSRF
+func newEngine(s *Session) *gin.Engine {
 	if *s.Options.Debug == true {
 		gin.SetMode(gin.DebugMode)
 	} else {
@@ -57,8 +111,15 @@ func NewRouter(s *Session) *gin.Engine {
 	}
 
 	router := gin.New()
-	router.Use(static.Serve("/", BinaryFileSystem("static")))
-	router.Use(secure.New(secure.Config{
+	if s.Auth != nil && s.Auth.Enabled() {
+		router.Use(s.Auth.Middleware())
+	}
+	router.Use(CsrfMiddleware(s.TLS != nil))
+	return router
+}
+
+func secureHeaders() gin.HandlerFunc {
+	return secure.New(secure.Config{
 		SSLRedirect:           false,
 		IsDevelopment:         false,
 		FrameDeny:             true,
@@ -66,64 +127,154 @@ func NewRouter(s *Session) *gin.Engine {
 		BrowserXssFilter:      true,
 		ContentSecurityPolicy: CspPolicy,
 		ReferrerPolicy:        ReferrerPolicy,
-	}))
-	router.GET("/stats", func(c *gin.Context) {
-		c.JSON(200, s.Stats)
 	})
-	router.GET("/findings", func(c *gin.Context) {
-		c.JSON(200, s.Findings)
-	})
-	router.GET("/targets", func(c *gin.Context) {
-		c.JSON(200, s.Targets)
-	})
-	router.GET("/repositories", func(c *gin.Context) {
-		c.JSON(200, s.Repositories)
-	})
-	router.GET("/files/:owner/:repo/:commit/*path", fetchFile)
-
-	return router
 }
 
-func fetchFile(c *gin.Context) {
-	fileUrl := fmt.Sprintf("%s/%s/%s/%s%s", GithubBaseUri, c.Param("owner"), c.Param("repo"), c.Param("commit"), c.Param("path"))
-	resp, err := http.Head(fileUrl)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err,
-		})
-		return
+// storedScanId returns the scan run to read from the database: the one
+// given with the scan query parameter, or else the session's own. Without a
+// database, requests are served from the session in memory.
+func storedScanId(c *gin.Context, s *Session) (string, bool) {
+	if s.Store == nil || s.Scan == nil {
+		return "", false
 	}
+	if id := c.Query("scan"); id != "" {
+		return id, true
+	}
+	return s.Scan.Id, true
+}
 
-	if resp.StatusCode == http.StatusNotFound {
+func respondFromStore(c *gin.Context, data interface{}, err error) {
+	if err == ErrScanNotFound {
 		c.JSON(http.StatusNotFound, gin.H{
-			"message": "No content",
+			"message": err.Error(),
 		})
 		return
-	}
-
-	if resp.ContentLength > MaximumFileSize {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{
-			"message": fmt.Sprintf("File size exceeds maximum of %d bytes", MaximumFileSize),
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"message": err.Error(),
 		})
 		return
 	}
+	c.JSON(http.StatusOK, data)
+}
 
-	resp, err = http.Get(fileUrl)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err,
-		})
-		return
+// listFindings responds with a page of the findings matching the filters,
+// sorting and cursor given in the query parameters
+func listFindings(s *Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, err := ParseFindingsQuery(c.Request.URL.Query())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+		if scan, ok := storedScanId(c, s); ok {
+			page, err := s.Store.QueryFindings(scan, query)
+			respondFromStore(c, page, err)
+			return
+		}
+		c.JSON(http.StatusOK, s.QueryFindings(query))
 	}
+}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err,
-		})
-		return
+// streamEvents streams the session's events to the client as Server-Sent
+// Events, starting with the complete stats
+func streamEvents(s *Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		events := s.Events.Subscribe()
+		defer s.Events.Unsubscribe(events)
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(200)
+
+		stats, _ := json.Marshal(s.statsSnapshot())
+		writeEvent(c.Writer, Event{Name: EventStats, Data: stats})
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(EventsHeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				writeEvent(c.Writer, event)
+			case <-heartbeat.C:
+				io.WriteString(c.Writer, ": heartbeat\n\n")
+			case <-c.Request.Context().Done():
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event Event) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, event.Data)
+}
+
+// triageFinding updates the triage status, assignee and note of a finding
+// with the fields given in the JSON request body
+func triageFinding(s *Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var update TriageUpdate
+		if err := c.ShouldBindJSON(&update); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": fmt.Sprintf("Invalid triage: %s", err),
+			})
+			return
+		}
+		if err := update.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+
+		finding, err := s.TriageFinding(c.Param("id"), update)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+		if finding == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "Finding not found",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, finding)
 	}
+}
+
+// fetchFile responds with the contents of a file in a commit, read from the
+// clone cache or the GitHub API
+func fetchFile(s *Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := strings.TrimPrefix(c.Param("path"), "/")
+		content, err := s.FileContents(c.Request.Context(), c.Param("owner"), c.Param("repo"), c.Param("commit"), path)
+		if err == ErrFileNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "No content",
+			})
+			return
+		} else if err == ErrFileTooLarge {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"message": fmt.Sprintf("File size exceeds maximum of %d bytes", *s.Options.MaxPreviewSize),
+			})
+			return
+		} else if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
 
-	c.String(http.StatusOK, string(body[:]))
+		c.String(http.StatusOK, string(content))
+	}
 }
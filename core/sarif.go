@@ -0,0 +1,265 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifFindingFingerprint = "gitrobFindingId/v1"
+	sarifSecretFingerprint  = "secretFingerprint/v1"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool                     sarifTool                        `json:"tool"`
+	Results                  []sarifResult                    `json:"results"`
+	VersionControlProvenance []sarifVersionControlDetails     `json:"versionControlProvenance,omitempty"`
+	OriginalURIBaseIDs       map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage       `json:"help,omitempty"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId,omitempty"`
+	RuleIndex           *int                   `json:"ruleIndex,omitempty"`
+	Level               string                 `json:"level"`
+	BaselineState       string                 `json:"baselineState,omitempty"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations"`
+	Fingerprints        map[string]string      `json:"fingerprints"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Suppressions        []sarifSuppression     `json:"suppressions,omitempty"`
+	Properties          map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifVersionControlDetails struct {
+	RepositoryURI string                `json:"repositoryUri"`
+	RevisionID    string                `json:"revisionId"`
+	MappedTo      sarifArtifactLocation `json:"mappedTo"`
+}
+
+// WriteSARIFReport writes the findings of a session as a SARIF 2.1.0 log.
+// Every repository and commit a finding was made in gets an entry in the
+// run's versionControlProvenance, which the finding's location refers to.
+func WriteSARIFReport(w io.Writer, s *Session) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           Name,
+				Version:        Version,
+				InformationURI: Website,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results:            []sarifResult{},
+		OriginalURIBaseIDs: make(map[string]sarifArtifactLocation),
+	}
+
+	ruleIndexes := make(map[string]int)
+	for i, rule := range ReportRules(s.Config, s.Findings) {
+		ruleIndexes[rule.Id] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleFromReportRule(rule))
+	}
+
+	for _, finding := range s.Findings {
+		baseID := sarifBaseID(finding)
+		if _, ok := run.OriginalURIBaseIDs[baseID]; !ok {
+			run.OriginalURIBaseIDs[baseID] = sarifArtifactLocation{
+				URI: fmt.Sprintf("%s/blob/%s/", finding.RepositoryUrl, finding.CommitHash),
+			}
+			run.VersionControlProvenance = append(run.VersionControlProvenance, sarifVersionControlDetails{
+				RepositoryURI: finding.RepositoryUrl,
+				RevisionID:    finding.CommitHash,
+				MappedTo:      sarifArtifactLocation{URIBaseID: baseID},
+			})
+		}
+		run.Results = append(run.Results, sarifResultFromFinding(finding, baseID, ruleIndexes))
+	}
+
+	log := sarifLog{
+		Version: SARIFVersion,
+		Schema:  SARIFSchema,
+		Runs:    []sarifRun{run},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func sarifRuleFromReportRule(rule ReportRule) sarifRule {
+	r := sarifRule{
+		ID:               rule.Id,
+		Name:             rule.Id,
+		ShortDescription: sarifMessage{Text: rule.Description},
+	}
+	if rule.Severity != "" {
+		r.DefaultConfiguration = &sarifConfiguration{Level: sarifLevel(rule.Severity)}
+	}
+	if rule.Comment != "" {
+		r.FullDescription = &sarifMessage{Text: rule.Comment}
+		r.Help = &sarifMessage{Text: rule.Comment}
+	}
+	return r
+}
+
+func sarifResultFromFinding(finding *Finding, baseID string, ruleIndexes map[string]int) sarifResult {
+	message := finding.Description
+	if finding.Comment != "" {
+		message = fmt.Sprintf("%s. %s", strings.TrimSuffix(message, "."), finding.Comment)
+	}
+	result := sarifResult{
+		RuleID:  finding.RuleId,
+		Level:   sarifLevel(finding.Severity),
+		Message: sarifMessage{Text: message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       ArchiveOuterPath(finding.FilePath),
+					URIBaseID: baseID,
+				},
+			},
+		}},
+		Fingerprints: map[string]string{
+			sarifFindingFingerprint: finding.Id,
+		},
+		Properties: map[string]interface{}{
+			"action":     finding.Action,
+			"commitHash": finding.CommitHash,
+			"author":     finding.CommitAuthor,
+			"severity":   finding.Severity,
+			"path":       finding.FilePath,
+			"repository": fmt.Sprintf("%s/%s", finding.RepositoryOwner, finding.RepositoryName),
+		},
+	}
+	if i, ok := ruleIndexes[finding.RuleId]; ok {
+		result.RuleIndex = &i
+	}
+	// Lines of files inside archives aren't lines of the archive the location
+	// points to
+	if finding.LineNumber > 0 && ArchiveOuterPath(finding.FilePath) == finding.FilePath {
+		result.Locations[0].PhysicalLocation.Region = &sarifRegion{StartLine: finding.LineNumber}
+	}
+	if finding.SecretFingerprint != "" {
+		result.PartialFingerprints = map[string]string{
+			sarifSecretFingerprint: finding.SecretFingerprint,
+		}
+	}
+	if finding.Encoding != "" {
+		result.Properties["encoding"] = finding.Encoding
+	}
+	if finding.Verification != "" {
+		result.Properties["verification"] = finding.Verification
+	}
+	if finding.Assignee != "" {
+		result.Properties["assignee"] = finding.Assignee
+	}
+	if suppression, ok := sarifSuppressions[finding.TriageStatus]; ok {
+		// Findings triaged in Gitrob are suppressed outside of the SARIF log
+		result.Properties["triageStatus"] = finding.TriageStatus
+		suppression.Kind = "external"
+		if finding.Note != "" {
+			suppression.Justification += ": " + finding.Note
+		}
+		result.Suppressions = []sarifSuppression{suppression}
+	}
+	result.BaselineState = sarifBaselineStates[finding.Change]
+	return result
+}
+
+// sarifSuppressions maps triage statuses to the status and justification of
+// the suppression of their findings. Only accepted risks are settled; false
+// positives and fixes are claims whose suppression is still to be reviewed.
+var sarifSuppressions = map[string]sarifSuppression{
+	TriageFalsePositive: {Status: "underReview", Justification: "False positive"},
+	TriageAccepted:      {Status: "accepted", Justification: "Accepted risk"},
+	TriageFixed:         {Status: "underReview", Justification: "Fixed"},
+}
+
+// sarifBaselineStates maps changes of findings compared to a baseline scan
+// to SARIF baseline states
+var sarifBaselineStates = map[string]string{
+	ChangeNew:       "new",
+	ChangeFixed:     "absent",
+	ChangeUnchanged: "unchanged",
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh, "":
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
+
+// sarifBaseID returns the URI base ID identifying the repository and commit
+// of a finding, e.g. acme/api@1a2b3c4d5e6f
+func sarifBaseID(finding *Finding) string {
+	commit := finding.CommitHash
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return fmt.Sprintf("%s/%s@%s", finding.RepositoryOwner, finding.RepositoryName, commit)
+}
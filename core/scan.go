@@ -0,0 +1,25 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// ScanRun identifies a single run of a scan
+type ScanRun struct {
+	Id         string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// NewScanRun returns a scan run started at startedAt with a new ID made of
+// the start time and a random suffix, e.g. 20181014T093012Z-5f2c9a
+func NewScanRun(startedAt time.Time) *ScanRun {
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	return &ScanRun{
+		Id:        startedAt.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix),
+		StartedAt: startedAt,
+	}
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SessionSchemaVersion is the version of the layout of session files written
+// by this version of Gitrob. Increase it and add a migration to
+// sessionMigrations whenever a change to Session or the types it contains
+// would break loading session files written before the change.
+const SessionSchemaVersion = 1
+
+// sessionMigration upgrades a decoded session file by one schema version,
+// returning warnings about what it couldn't recover
+type sessionMigration func(session map[string]interface{}) ([]string, error)
+
+// sessionMigrations upgrade session files written with older layouts. The
+// migration at index i upgrades a session of schema version i to i+1.
+// Session files written before schema versions were introduced are version 0.
+var sessionMigrations = []sessionMigration{
+	migrateSessionV0,
+}
+
+// decodeSession decodes a session file of any schema version up to
+// SessionSchemaVersion into s, migrating it to the current layout first.
+// It returns the warnings of the migrations.
+func decodeSession(data []byte, s *Session) ([]string, error) {
+	var session map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keeps IDs from losing precision as float64
+	if err := decoder.Decode(&session); err != nil {
+		return nil, fmt.Errorf("is not a valid session file: %v", err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("is not a valid session file")
+	}
+
+	version := 0
+	if value, ok := session["SchemaVersion"]; ok {
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("has an invalid schema version %v", value)
+		}
+		v, err := number.Int64()
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("has an invalid schema version %v", value)
+		}
+		version = int(v)
+	}
+	if version > SessionSchemaVersion {
+		return nil, fmt.Errorf("was generated by a newer version of Gitrob (schema version %d, this version supports up to %d)", version, SessionSchemaVersion)
+	}
+
+	var warnings []string
+	for ; version < SessionSchemaVersion; version++ {
+		w, err := sessionMigrations[version](session)
+		if err != nil {
+			return nil, fmt.Errorf("could not be upgraded from schema version %d: %v", version, err)
+		}
+		warnings = append(warnings, w...)
+	}
+	session["SchemaVersion"] = SessionSchemaVersion
+
+	migrated, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(migrated, s); err != nil {
+		return nil, fmt.Errorf("is corrupt: %v", err)
+	}
+	return warnings, nil
+}
+
+// migrateSessionV0 upgrades session files written before schema versions
+// were introduced. Their layout is the same, but older versions of Gitrob
+// didn't fill in every field that later versions rely on: targets and
+// repositories without an ID are dropped, as they can't be told apart,
+// missing finding IDs and URLs are generated, findings without a severity
+// get the default severity of rules, and missing stats are counted. Rule
+// IDs, secret fingerprints and scan options can't be recovered, so there's a
+// warning for each that is missing.
+func migrateSessionV0(session map[string]interface{}) ([]string, error) {
+	for _, key := range []string{"Targets", "Repositories"} {
+		items, _ := session[key].([]interface{})
+		var kept []interface{}
+		for _, item := range items {
+			if fields, ok := item.(map[string]interface{}); ok && fields["ID"] != nil {
+				kept = append(kept, item)
+			}
+		}
+		session[key] = kept
+	}
+
+	var noSeverity, noRule, fingerprinted int
+	findings, _ := session["Findings"].([]interface{})
+	for i, item := range findings {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var finding Finding
+		if err := json.Unmarshal(data, &finding); err != nil {
+			return nil, fmt.Errorf("finding %d: %v", i+1, err)
+		}
+		if finding.FileUrl == "" {
+			finding.setupUrls()
+		}
+		if finding.Id == "" {
+			finding.generateID()
+		}
+		if finding.Severity == "" {
+			finding.Severity = DefaultSeverity
+			noSeverity++
+		}
+		if finding.RuleId == "" {
+			noRule++
+		}
+		if finding.SecretFingerprint != "" {
+			fingerprinted++
+		}
+		findings[i] = &finding
+	}
+
+	var warnings []string
+	if noSeverity > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d %s had no severity and %s given the default severity %s", noSeverity, Pluralize(noSeverity, "finding", "findings"), Pluralize(noSeverity, "was", "were"), DefaultSeverity))
+	}
+	if noRule > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d %s %s no rule ID, so %s left out of rule summaries and filters", noRule, Pluralize(noRule, "finding", "findings"), Pluralize(noRule, "has", "have"), Pluralize(noRule, "it is", "they are")))
+	}
+	// Findings of file names and paths have no secret to fingerprint, so
+	// fingerprints are only known to be missing if no finding has one
+	if len(findings) > 0 && fingerprinted == 0 {
+		warnings = append(warnings, "findings have no secret fingerprints, so they're matched to findings of other scans by commit rather than by secret")
+	}
+	if session["ScanOptions"] == nil {
+		warnings = append(warnings, "the options the session was scanned with weren't recorded")
+	}
+
+	if session["Stats"] == nil {
+		targets, _ := session["Targets"].([]interface{})
+		repositories, _ := session["Repositories"].([]interface{})
+		session["Stats"] = map[string]interface{}{
+			"Status":       StatusFinished,
+			"Progress":     100,
+			"Targets":      len(targets),
+			"Repositories": len(repositories),
+			"Findings":     len(findings),
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		err      string
+		findings int
+		severity string
+		warnings []string
+	}{
+		{
+			name:     "version 0",
+			data:     `{"Targets":[{"ID":1},{}],"Findings":[{"FilePath":".env","Description":"AWS key","RepositoryOwner":"acme","RepositoryName":"api","CommitHash":"c1"}]}`,
+			findings: 1,
+			severity: DefaultSeverity,
+			warnings: []string{"default severity", "no rule ID", "no secret fingerprints", "options"},
+		},
+		{
+			name:     "version 0 with rule IDs",
+			data:     `{"ScanOptions":{},"Findings":[{"Id":"a","RuleId":"aws","Severity":"high","SecretFingerprint":"f"},{"Id":"b","RuleId":"env_file","Severity":"low"}]}`,
+			findings: 2,
+			severity: "high",
+		},
+		{
+			name:     "current version",
+			data:     `{"SchemaVersion":1,"Findings":[{"Id":"a"}]}`,
+			findings: 1,
+		},
+		{name: "newer version", data: `{"SchemaVersion":99}`, err: "newer version"},
+		{name: "invalid version", data: `{"SchemaVersion":"1"}`, err: "invalid schema version"},
+		{name: "not a session", data: `[]`, err: "not a valid session file"},
+		{name: "null", data: `null`, err: "not a valid session file"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var s Session
+			warnings, err := decodeSession([]byte(test.data), &s)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.SchemaVersion != SessionSchemaVersion {
+				t.Errorf("got schema version %d, want %d", s.SchemaVersion, SessionSchemaVersion)
+			}
+			if len(s.Findings) != test.findings {
+				t.Fatalf("got %d findings, want %d", len(s.Findings), test.findings)
+			}
+			if test.findings > 0 {
+				if s.Findings[0].Severity != test.severity {
+					t.Errorf("got severity %q, want %q", s.Findings[0].Severity, test.severity)
+				}
+				if s.Findings[0].Id == "" {
+					t.Errorf("finding has no ID")
+				}
+			}
+			if len(warnings) != len(test.warnings) {
+				t.Fatalf("got warnings %q, want %d", warnings, len(test.warnings))
+			}
+			for i, warning := range warnings {
+				if !strings.Contains(warning, test.warnings[i]) {
+					t.Errorf("got warning %q, want one about %q", warning, test.warnings[i])
+				}
+			}
+		})
+	}
+}
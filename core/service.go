@@ -0,0 +1,422 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultScanRetention = time.Hour
+
+var ruleSetName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*$`)
+
+// ScanRequest is the body of a request to start a scan with gitrob serve.
+// Fields left empty default to the options gitrob serve was started with.
+type ScanRequest struct {
+	Targets      []string // Users and organizations to scan the repositories of
+	Repositories []string // Repositories to scan, as owner/name
+	RuleSet      string   // Name of a config file in the -rules-dir directory, without .yaml
+	CommitDepth  int      // Number of commits to process in each repository
+	NoExpandOrgs bool     // Don't add members of organizations to the targets
+}
+
+func (r *ScanRequest) Validate() error {
+	if len(r.Targets) == 0 && len(r.Repositories) == 0 {
+		return errors.New("a scan needs Targets or Repositories")
+	}
+	for _, target := range r.Targets {
+		if strings.TrimSpace(target) == "" || strings.Contains(target, "/") {
+			return fmt.Errorf("invalid target %q, use the login of a user or organization", target)
+		}
+	}
+	for _, repository := range r.Repositories {
+		parts := strings.Split(repository, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid repository %q, use owner/name", repository)
+		}
+	}
+	if r.RuleSet != "" && !ruleSetName.MatchString(r.RuleSet) {
+		return fmt.Errorf("invalid rule set %q", r.RuleSet)
+	}
+	if r.CommitDepth < 0 {
+		return errors.New("CommitDepth can't be negative")
+	}
+	return nil
+}
+
+// ServedScan is a scan started through the API of gitrob serve
+type ServedScan struct {
+	sync.Mutex
+
+	Id      string
+	Request ScanRequest
+	Error   string   // Why the scan failed
+	Stats   *Stats   // Stats of the scan, with its status
+	Session *Session `json:"-"`
+}
+
+// MarshalJSON encodes the scan, locking its stats as they change while the
+// scan runs
+func (sc *ServedScan) MarshalJSON() ([]byte, error) {
+	sc.Stats.Lock()
+	defer sc.Stats.Unlock()
+	sc.Lock()
+	defer sc.Unlock()
+	return json.Marshal(struct {
+		Id      string
+		Request ScanRequest
+		Error   string `json:",omitempty"`
+		Stats   *Stats
+	}{sc.Id, sc.Request, sc.Error, sc.Stats})
+}
+
+// IsRunning reports whether the scan hasn't ended yet
+func (sc *ServedScan) IsRunning() bool {
+	sc.Stats.Lock()
+	defer sc.Stats.Unlock()
+	switch sc.Stats.Status {
+	case StatusFinished, StatusCancelled, StatusFailed:
+		return false
+	}
+	return true
+}
+
+// finishedBefore reports whether the scan ended before t
+func (sc *ServedScan) finishedBefore(t time.Time) bool {
+	sc.Stats.Lock()
+	defer sc.Stats.Unlock()
+	return !sc.Stats.FinishedAt.IsZero() && sc.Stats.FinishedAt.Before(t)
+}
+
+// ScanFunc gathers the repositories of a requested scan and analyzes them
+// with the pool
+type ScanFunc func(s *Session, pool *RepositoryPool, request ScanRequest) error
+
+// ScanService starts and keeps track of the scans requested through the API
+// of gitrob serve. Scans run concurrently, and their repositories are
+// analyzed by a shared pool of workers. Finished scans are dropped from
+// memory after the retention period, or when they're deleted; with -db they
+// stay in the database.
+type ScanService struct {
+	sync.Mutex
+
+	Server    *Session // Session of gitrob serve, with the options scans default to
+	RulesDir  string   // Directory of the config files scans can select by name
+	Pool      *RepositoryPool
+	Retention time.Duration // How long finished scans are kept
+
+	run   ScanFunc
+	scans map[string]*ServedScan
+	order []*ServedScan
+}
+
+// NewScanService returns a service that runs scans with run, and starts
+// dropping finished scans after the retention period
+func NewScanService(server *Session, rulesDir string, pool *RepositoryPool, retention time.Duration, run ScanFunc) *ScanService {
+	sv := &ScanService{
+		Server:    server,
+		RulesDir:  rulesDir,
+		Pool:      pool,
+		Retention: retention,
+		run:       run,
+		scans:     make(map[string]*ServedScan),
+	}
+	go sv.evictFinished()
+	return sv
+}
+
+// Start starts a scan in the background
+func (sv *ScanService) Start(request ScanRequest) (*ServedScan, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if request.RuleSet != "" && sv.RulesDir == "" {
+		return nil, errors.New("rule sets can't be selected as gitrob serve was started without -rules-dir")
+	}
+	s, err := sv.newSession(request)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &ServedScan{Id: s.Scan.Id, Request: request, Stats: s.Stats, Session: s}
+	sv.Lock()
+	sv.scans[scan.Id] = scan
+	sv.order = append(sv.order, scan)
+	sv.Unlock()
+
+	sv.Server.Out.Important("Started scan %s\n", scan.Id)
+	go func() {
+		err := sv.run(s, sv.Pool, request)
+		switch {
+		case s.IsCancelled():
+			s.FinishWithStatus(StatusCancelled)
+		case err != nil:
+			scan.Lock()
+			scan.Error = err.Error()
+			scan.Unlock()
+			s.FinishWithStatus(StatusFailed)
+		default:
+			s.Finish()
+		}
+		sv.Server.Out.Important("Scan %s %s with %d %s\n", scan.Id, s.Stats.Status, s.Stats.Findings, Pluralize(s.Stats.Findings, "finding", "findings"))
+	}()
+	return scan, nil
+}
+
+// Scan returns the scan with the ID, or nil if there's no such scan
+func (sv *ScanService) Scan(id string) *ServedScan {
+	sv.Lock()
+	defer sv.Unlock()
+	return sv.scans[id]
+}
+
+// Scans returns all scans in the order they were started
+func (sv *ScanService) Scans() []*ServedScan {
+	sv.Lock()
+	defer sv.Unlock()
+	return append([]*ServedScan{}, sv.order...)
+}
+
+// Remove drops a finished scan. It returns nil if there's no such scan.
+func (sv *ScanService) Remove(id string) (*ServedScan, error) {
+	scan := sv.Scan(id)
+	if scan == nil {
+		return nil, nil
+	}
+	if scan.IsRunning() {
+		return scan, errors.New("scan is still running, cancel it first")
+	}
+	sv.remove(scan)
+	return scan, nil
+}
+
+func (sv *ScanService) remove(scan *ServedScan) {
+	sv.Lock()
+	defer sv.Unlock()
+	if _, ok := sv.scans[scan.Id]; !ok {
+		return
+	}
+	delete(sv.scans, scan.Id)
+	for i, s := range sv.order {
+		if s == scan {
+			sv.order = append(sv.order[:i], sv.order[i+1:]...)
+			break
+		}
+	}
+	sv.Server.Metrics.Retire(scan.Session)
+}
+
+// evictFinished drops scans that finished longer than the retention period
+// ago
+func (sv *ScanService) evictFinished() {
+	interval := time.Minute
+	if sv.Retention < interval {
+		interval = sv.Retention
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		cutoff := time.Now().Add(-sv.Retention)
+		for _, scan := range sv.Scans() {
+			if scan.finishedBefore(cutoff) {
+				sv.remove(scan)
+				sv.Server.Out.Debug("Dropped scan %s\n", scan.Id)
+			}
+		}
+	}
+}
+
+// Cancel cancels a running scan. It returns nil if there's no such scan.
+func (sv *ScanService) Cancel(id string) (*ServedScan, error) {
+	scan := sv.Scan(id)
+	if scan == nil {
+		return nil, nil
+	}
+	if !scan.IsRunning() {
+		return scan, errors.New("scan has already ended")
+	}
+	scan.Session.Cancel()
+	sv.Server.Out.Important("Cancelling scan %s\n", scan.Id)
+	return scan, nil
+}
+
+// newSession returns the session of a requested scan, with the options of
+// the server and the clients and caches it shares with other scans
+func (sv *ScanService) newSession(request ScanRequest) (*Session, error) {
+	options := sv.Server.Options
+	options.Logins = request.Targets
+	if request.CommitDepth > 0 {
+		depth := request.CommitDepth
+		options.CommitDepth = &depth
+	}
+	noExpandOrgs := request.NoExpandOrgs || *options.NoExpandOrgs
+	options.NoExpandOrgs = &noExpandOrgs
+	if request.RuleSet != "" {
+		configPath := filepath.Join(sv.RulesDir, request.RuleSet+".yaml")
+		options.ConfigPath = &configPath
+	}
+
+	s := &Session{
+		Version:           Version,
+		SchemaVersion:     SessionSchemaVersion,
+		Options:           options,
+		ScanOptions:       &options,
+		Out:               sv.Server.Out,
+		GithubAccessToken: sv.Server.GithubAccessToken,
+		GithubClient:      sv.Server.GithubClient,
+		CloneCache:        sv.Server.CloneCache,
+		FingerprintKey:    sv.Server.FingerprintKey,
+		Output:            sv.Server.Output,
+		Store:             sv.Server.Store,
+		Metrics:           sv.Server.Metrics,
+	}
+	s.InitContext()
+	s.InitStats()
+	if err := s.LoadSignatures(); err != nil {
+		if request.RuleSet != "" {
+			return nil, fmt.Errorf("failed to load rule set %s: %v", request.RuleSet, err)
+		}
+		return nil, err
+	}
+	if err := s.LoadVerifiers(); err != nil {
+		return nil, fmt.Errorf("failed to initialize verifiers: %v", err)
+	}
+	s.InitScan()
+	s.store(func(st *Store) error {
+		return st.PutScan(s.Scan, s.Stats)
+	})
+	return s, nil
+}
+
+// NewRouter returns the router of the API of gitrob serve
+func (sv *ScanService) NewRouter(s *Session) *gin.Engine {
+	router := newEngine(s)
+	router.Use(secureHeaders())
+	router.GET("/scans", func(c *gin.Context) {
+		c.JSON(http.StatusOK, sv.Scans())
+	})
+	router.POST("/scans", func(c *gin.Context) {
+		var request ScanRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": fmt.Sprintf("Invalid scan: %s", err),
+			})
+			return
+		}
+		scan, err := sv.Start(request)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+		c.Header("Location", "/scans/"+scan.Id)
+		c.JSON(http.StatusCreated, scan)
+	})
+	router.GET("/scans/:id", func(c *gin.Context) {
+		if scan := sv.scanOrNotFound(c); scan != nil {
+			c.JSON(http.StatusOK, scan)
+		}
+	})
+	router.DELETE("/scans/:id", func(c *gin.Context) {
+		// Running scans are cancelled, and finished ones dropped
+		scan := sv.scanOrNotFound(c)
+		if scan == nil {
+			return
+		}
+		if !scan.IsRunning() {
+			if _, err := sv.Remove(scan.Id); err != nil {
+				c.JSON(http.StatusConflict, gin.H{
+					"message": err.Error(),
+				})
+				return
+			}
+			c.Status(http.StatusNoContent)
+			return
+		}
+		scan, err := sv.Cancel(scan.Id)
+		if scan == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "Scan not found",
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusConflict, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, scan)
+	})
+	router.GET("/metrics", MetricsHandler(s.Metrics, func() []*Session {
+		var sessions []*Session
+		for _, scan := range sv.Scans() {
+			sessions = append(sessions, scan.Session)
+		}
+		return sessions
+	}))
+	router.GET("/scans/:id/findings", func(c *gin.Context) {
+		scan := sv.scanOrNotFound(c)
+		if scan == nil {
+			return
+		}
+		query, err := ParseFindingsQuery(c.Request.URL.Query())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, scan.Session.QueryFindings(query))
+	})
+	return router
+}
+
+func (sv *ScanService) scanOrNotFound(c *gin.Context) *ServedScan {
+	scan := sv.Scan(c.Param("id"))
+	if scan == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"message": "Scan not found",
+		})
+	}
+	return scan
+}
+
+// NewServerSession returns the session of gitrob serve, which holds the
+// options, clients and caches the scans it starts share
+func NewServerSession(options Options) (*Session, error) {
+	s := &Session{
+		Version:       Version,
+		SchemaVersion: SessionSchemaVersion,
+		Options:       options,
+	}
+	s.InitContext()
+	s.InitStats()
+	s.InitMetrics()
+	s.InitLogger()
+	s.InitOutput()
+	s.InitThreads()
+	s.InitGithubAccessToken()
+	s.InitGithubClient()
+	// Scans load their own rules, but the default rules are checked up front
+	s.InitSignatures()
+	s.InitFingerprintKey()
+	s.InitCloneCache()
+	if *options.Database != "" {
+		store, err := OpenStore(*options.Database)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database %s: %v", *options.Database, err)
+		}
+		s.Store = store
+	}
+	return s, nil
+}
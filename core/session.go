@@ -2,12 +2,15 @@ package core
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"net"
+	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
@@ -23,80 +26,243 @@ const (
 	StatusGathering    = "gathering"
 	StatusAnalyzing    = "analyzing"
 	StatusFinished     = "finished"
+	StatusCancelled    = "cancelled"
+	StatusFailed       = "failed"
 )
 
 type Stats struct {
 	sync.Mutex
 
-	StartedAt    time.Time
-	FinishedAt   time.Time
-	Status       string
-	Progress     float64
-	Targets      int
-	Repositories int
-	Commits      int
-	Files        int
-	Findings     int
+	StartedAt            time.Time
+	FinishedAt           time.Time
+	Status               string
+	Progress             float64
+	Targets              int
+	Repositories         int
+	Commits              int
+	Files                int
+	Findings             int
+	SkippedRegexes       int // Content signature evaluations avoided by the keyword prefilter
+	TimedOutRegexes      int
+	SkippedFiles         int
+	TimedOutRepositories int
+	Errors               int // Errors that left part of the scan incomplete, e.g. failed clones
+}
+
+// SkippedFile records a file that was not scanned, or only partially scanned
+type SkippedFile struct {
+	RepositoryOwner string
+	RepositoryName  string
+	CommitHash      string
+	FilePath        string
+	Reason          string
 }
 
 type Session struct {
 	sync.Mutex
 
 	Version           string
+	SchemaVersion     int     // Layout of the session file, see SessionSchemaVersion
 	Options           Options `json:"-"`
 	Out               *Logger `json:"-"`
 	Stats             *Stats
-	GithubAccessToken string         `json:"-"`
-	GithubClient      *github.Client `json:"-"`
-	Router            *gin.Engine    `json:"-"`
+	GithubAccessToken string            `json:"-"`
+	GithubClient      *github.Client    `json:"-"`
+	CloneCache        *CloneCache       `json:"-"`
+	Router            *gin.Engine       `json:"-"`
+	Auth              *WebAuth          `json:"-"`
+	TLS               *tls.Config       `json:"-"`
+	Config            *Config           `json:"-"`
+	Signatures        []Signature       `json:"-"` // Signatures converted from the patterns of Config
+	Prefilter         *Prefilter        `json:"-"` // Keyword prefilter built over Signatures
+	Verifiers         *VerificationPool `json:"-"`
+	Output            *FindingWriter    `json:"-"`
+	Store             *Store            `json:"-"`
+	Key               *FileKey          `json:"-"` // Encrypts session, checkpoint and report files
+	FingerprintKey    []byte            `json:"-"` // Key secrets are fingerprinted with
+	Events            *EventBroker      `json:"-"` // Publishes changes to web interface clients
+	Metrics           *Metrics          `json:"-"` // Exported on /metrics
+	LoadedFromStore   bool              `json:"-"` // Session shows a scan run loaded from the database
+	Scan              *ScanRun          `json:",omitempty"`
+	Baseline          *ScanRun          `json:",omitempty"` // Scan run the findings were compared to with gitrob diff
+	RuleSet           string            `json:",omitempty"` // Hash of the rules the session was scanned with
+	ScanOptions       *Options          `json:",omitempty"` // Options the session was scanned with
 	Targets           []*GithubOwner
 	Repositories      []*GithubRepository
 	Findings          []*Finding
+	SkippedFiles      []*SkippedFile
+	// Full names of repositories whose analysis exceeded the repository timeout
+	TimedOutRepositories []string
+	// Full names of repositories whose analysis has ended, for resuming scans
+	CompletedRepositories []string
+
+	targetIds             map[int64]bool
+	repositoryIds         map[int64]bool
+	completedRepositories map[string]bool
+	loadWarnings          []string            // What couldn't be recovered from an older session file
+	triageSave            sync.Mutex          // Serializes writes of triage to the session file
+	triage                map[string]*Finding // Findings with triage to carry forward, by SecretKey
+	checkpointLocation    string
+	checkpointStop        chan struct{}
+	checkpointDone        chan struct{}
+	ctx                   context.Context
+	cancel                context.CancelFunc
 }
 
 func (s *Session) Start() {
+	s.InitContext()
 	s.InitStats()
+	s.InitMetrics()
 	s.InitLogger()
+	s.InitOutput()
 	s.InitThreads()
 	s.InitGithubAccessToken()
 	s.InitGithubClient()
 	s.InitSignatures()
+	s.InitFingerprintKey()
+	s.InitVerifiers()
+	s.InitScan()
+	s.InitTriage()
+	s.InitStore()
+	s.InitCloneCache()
 	if !*s.Options.NoWebServer {
+		s.InitEvents()
 		s.InitRouter()
 	}
+	s.InitCheckpoints()
 }
 
 func (s *Session) Finish() {
-	s.Stats.FinishedAt = time.Now()
-	s.Stats.Status = StatusFinished
+	s.FinishWithStatus(StatusFinished)
+}
+
+// FinishWithStatus ends the scan with the given status, e.g. StatusCancelled
+// for scans that were cancelled
+func (s *Session) FinishWithStatus(status string) {
+	if s.Verifiers != nil {
+		s.Verifiers.Wait()
+	}
+	s.Stats.Finish(status)
+	s.Scan.FinishedAt = s.Stats.FinishedAt
+	s.stopCheckpoints()
+	s.store(func(st *Store) error {
+		return st.PutScan(s.Scan, s.Stats)
+	})
+}
+
+// InitContext creates the context the session's scan runs in, which is done
+// once the scan is cancelled
+func (s *Session) InitContext() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+// Context returns the context the session's scan runs in
+func (s *Session) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// Cancel cancels the session's scan. Repositories being analyzed are left at
+// the next file, and repositories that haven't been started are skipped.
+func (s *Session) Cancel() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// IsCancelled reports whether the session's scan was cancelled
+func (s *Session) IsCancelled() bool {
+	return s.Context().Err() != nil
 }
 
 func (s *Session) AddTarget(target *GithubOwner) {
 	s.Lock()
-	defer s.Unlock()
-	for _, t := range s.Targets {
-		if *target.ID == *t.ID {
-			return
+	if s.targetIds == nil {
+		s.targetIds = make(map[int64]bool)
+		for _, t := range s.Targets {
+			s.targetIds[*t.ID] = true
 		}
 	}
+	if s.targetIds[*target.ID] {
+		s.Unlock()
+		return
+	}
+	s.targetIds[*target.ID] = true
 	s.Targets = append(s.Targets, target)
+	s.Unlock()
+	s.store(func(st *Store) error {
+		return st.PutOwner(s.Scan, target)
+	})
 }
 
 func (s *Session) AddRepository(repository *GithubRepository) {
 	s.Lock()
-	defer s.Unlock()
-	for _, r := range s.Repositories {
-		if *repository.ID == *r.ID {
-			return
+	if s.repositoryIds == nil {
+		s.repositoryIds = make(map[int64]bool)
+		for _, r := range s.Repositories {
+			s.repositoryIds[*r.ID] = true
 		}
 	}
+	if s.repositoryIds[*repository.ID] {
+		s.Unlock()
+		return
+	}
+	s.repositoryIds[*repository.ID] = true
 	s.Repositories = append(s.Repositories, repository)
+	s.Unlock()
+	s.store(func(st *Store) error {
+		return st.PutRepository(s.Scan, repository)
+	})
 }
 
 func (s *Session) AddFinding(finding *Finding) {
+	// The finding isn't shared yet, so its triage is looked up before locking
+	s.carryTriage(finding)
 	s.Lock()
-	defer s.Unlock()
 	s.Findings = append(s.Findings, finding)
+	if s.Output != nil {
+		if err := s.Output.Write(finding); err != nil {
+			s.ScanError("Error writing finding to %s: %s\n", *s.Options.Output, err)
+		}
+	}
+	s.Events.Publish(EventFinding, finding)
+	stored := *finding
+	s.Unlock()
+	s.store(func(st *Store) error {
+		return st.PutFinding(s.Scan, &stored)
+	})
+}
+
+// ScanError logs an error that left part of the scan incomplete and counts
+// it, so the scan isn't reported as clean.
+func (s *Session) ScanError(format string, args ...interface{}) {
+	s.Out.Error(format, args...)
+	s.Stats.IncrementErrors()
+}
+
+func (s *Session) SetVerification(finding *Finding, status string) {
+	s.Lock()
+	finding.Verification = status
+	s.Events.Publish(EventFinding, finding)
+	stored := *finding
+	s.Unlock()
+	s.store(func(st *Store) error {
+		return st.PutFinding(s.Scan, &stored)
+	})
+}
+
+func (s *Session) AddSkippedFile(file *SkippedFile) {
+	s.Lock()
+	defer s.Unlock()
+	s.SkippedFiles = append(s.SkippedFiles, file)
+}
+
+func (s *Session) AddTimedOutRepository(repository *GithubRepository) {
+	s.Lock()
+	defer s.Unlock()
+	s.TimedOutRepositories = append(s.TimedOutRepositories, *repository.FullName)
 }
 
 func (s *Session) InitStats() {
@@ -115,10 +281,34 @@ func (s *Session) InitStats() {
 	}
 }
 
+// InitScan gives the session a scan run, unless it continues or loads one.
+// Sessions saved by older versions get a scan run when loaded.
+func (s *Session) InitScan() {
+	if s.Scan != nil {
+		return
+	}
+	s.Scan = NewScanRun(s.Stats.StartedAt)
+	s.Scan.FinishedAt = s.Stats.FinishedAt
+}
+
 func (s *Session) InitLogger() {
 	s.Out = &Logger{}
 	s.Out.SetDebug(*s.Options.Debug)
 	s.Out.SetSilent(*s.Options.Silent)
+	if *s.Options.Output == "-" {
+		s.Out.SetOutput(os.Stderr)
+	}
+}
+
+func (s *Session) InitOutput() {
+	if *s.Options.Output == "" {
+		return
+	}
+	output, err := NewFindingWriter(*s.Options.Output)
+	if err != nil {
+		s.Out.Fatal("Failed to open %s for writing findings: %s\n", *s.Options.Output, err)
+	}
+	s.Output = output
 }
 
 func (s *Session) InitGithubAccessToken() {
@@ -139,10 +329,15 @@ func (s *Session) InitGithubClient() {
 		&oauth2.Token{AccessToken: s.GithubAccessToken},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = &rateLimitTransport{base: tc.Transport, metrics: s.Metrics}
 	s.GithubClient = github.NewClient(tc)
 	s.GithubClient.UserAgent = fmt.Sprintf("%s v%s", Name, Version)
 }
 
+func (s *Session) InitMetrics() {
+	s.Metrics = NewMetrics()
+}
+
 func (s *Session) InitThreads() {
 	if *s.Options.Threads == 0 {
 		numCPUs := runtime.NumCPU()
@@ -152,25 +347,116 @@ func (s *Session) InitThreads() {
 }
 
 func (s *Session) InitRouter() {
+	s.StartWebServer(NewRouter)
+}
+
+// StartWebServer loads the credentials and TLS certificate of the web
+// server, and serves the router made by newRouter in the background
+func (s *Session) StartWebServer(newRouter func(s *Session) *gin.Engine) {
 	bind := fmt.Sprintf("%s:%d", *s.Options.BindAddress, *s.Options.Port)
-	s.Router = NewRouter(s)
+	auth, err := LoadWebAuth(*s.Options.Htpasswd)
+	if err != nil {
+		s.Out.Fatal("Failed to load web interface credentials: %s\n", err)
+	}
+	s.Auth = auth
+	if !s.Auth.Enabled() && !isLoopback(*s.Options.BindAddress) {
+		s.Out.Warn("Web interface on %s is accessible without authentication. Set %s or use -htpasswd to require sign in.\n", bind, WebTokenEnvVariable)
+	}
+	s.TLS, err = LoadTLSConfig(*s.Options.TLSCert, *s.Options.TLSKey, *s.Options.TLSSelfSigned, *s.Options.BindAddress)
+	if err != nil {
+		s.Out.Fatal("Failed to load TLS certificate: %s\n", err)
+	}
+	if *s.Options.TLSSelfSigned {
+		s.Out.Info("Generated self-signed certificate with SHA-256 fingerprint %s\n", CertificateFingerprint(s.TLS.Certificates[0]))
+	}
+
+	s.Router = newRouter(s)
+	server := &http.Server{
+		Addr:      bind,
+		Handler:   s.Router,
+		TLSConfig: s.TLS,
+	}
 	go func(sess *Session) {
-		if err := sess.Router.Run(bind); err != nil {
+		var err error
+		if sess.TLS != nil {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
 			sess.Out.Fatal("Error when starting web server: %s\n", err)
 		}
 	}(s)
 }
 
+// WebURL returns the URL of the web interface
+func (s *Session) WebURL() string {
+	scheme := "http"
+	if s.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(*s.Options.BindAddress, strconv.Itoa(*s.Options.Port)))
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func (s *Session) InitSignatures() {
 	// Load config from the required config path
+	if err := s.LoadSignatures(); err != nil {
+		s.Out.Fatal("Failed to load config from %s: %s\n", *s.Options.ConfigPath, err)
+	}
+	s.Out.Debug("Loaded %d signatures from %s\n", len(s.Signatures), *s.Options.ConfigPath)
+}
+
+// LoadSignatures loads the config file given with -config and converts its
+// patterns to the signatures the session's files are matched against
+func (s *Session) LoadSignatures() error {
 	config, err := LoadConfig(*s.Options.ConfigPath)
 	if err != nil {
-		s.Out.Fatal("Failed to load config from %s: %s\n", *s.Options.ConfigPath, err)
+		return err
+	}
+
+	s.Config = config
+
+	// A loaded session keeps the hash of the rules its findings were made with
+	if *s.Options.Load == "" {
+		ruleSet := config.RuleSetHash()
+		if s.RuleSet != "" && s.RuleSet != ruleSet {
+			s.Out.Warn("Rules in %s differ from the rules the resumed scan was started with.\n", *s.Options.ConfigPath)
+		}
+		s.RuleSet = ruleSet
 	}
 
 	// Convert config patterns to signatures
-	Signatures = config.ConvertToSignatures()
-	s.Out.Debug("Loaded %d signatures from %s\n", len(Signatures), *s.Options.ConfigPath)
+	s.Signatures = config.ConvertToSignatures()
+	s.Prefilter = NewPrefilter(s.Signatures)
+	return nil
+}
+
+func (s *Session) InitVerifiers() {
+	if err := s.LoadVerifiers(); err != nil {
+		s.Out.Fatal("Failed to initialize verifiers: %s\n", err)
+	}
+}
+
+// LoadVerifiers starts the verification pool for the session's signatures
+// if -verify is set
+func (s *Session) LoadVerifiers() error {
+	if !*s.Options.Verify {
+		return nil
+	}
+	pool, err := NewVerificationPool(s.Signatures, s.Config.Verifiers, *s.Options.VerifyThreads)
+	if err != nil {
+		return err
+	}
+	s.Verifiers = pool
+	return nil
 }
 
 func (s *Session) SaveToFile(location string) error {
@@ -178,11 +464,15 @@ func (s *Session) SaveToFile(location string) error {
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(location, sessionJson, 0644)
+	f, err := CreateFile(location, s.Key)
 	if err != nil {
 		return err
 	}
-	return nil
+	if _, err := f.Write(sessionJson); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
 
 func (s *Stats) IncrementTargets() {
@@ -215,6 +505,51 @@ func (s *Stats) IncrementFindings() {
 	s.Findings++
 }
 
+func (s *Stats) AddSkippedRegexes(count int) {
+	s.Lock()
+	defer s.Unlock()
+	s.SkippedRegexes += count
+}
+
+func (s *Stats) AddTimedOutRegexes(count int) {
+	s.Lock()
+	defer s.Unlock()
+	s.TimedOutRegexes += count
+}
+
+func (s *Stats) IncrementSkippedFiles() {
+	s.Lock()
+	defer s.Unlock()
+	s.SkippedFiles++
+}
+
+func (s *Stats) IncrementTimedOutRepositories() {
+	s.Lock()
+	defer s.Unlock()
+	s.TimedOutRepositories++
+}
+
+func (s *Stats) IncrementErrors() {
+	s.Lock()
+	defer s.Unlock()
+	s.Errors++
+}
+
+// SetStatus sets the phase of the scan, e.g. StatusAnalyzing
+func (s *Stats) SetStatus(status string) {
+	s.Lock()
+	defer s.Unlock()
+	s.Status = status
+}
+
+// Finish ends the scan with the given status, e.g. StatusFinished
+func (s *Stats) Finish(status string) {
+	s.Lock()
+	defer s.Unlock()
+	s.FinishedAt = time.Now()
+	s.Status = status
+}
+
 func (s *Stats) UpdateProgress(current int, total int) {
 	s.Lock()
 	defer s.Unlock()
@@ -225,6 +560,29 @@ func (s *Stats) UpdateProgress(current int, total int) {
 	}
 }
 
+// LoadFromFile reads a session file saved with -save into the session
+func (s *Session) LoadFromFile(location string) error {
+	if !FileExists(location) {
+		return errors.New(fmt.Sprintf("Session file %s does not exist or is not readable.", location))
+	}
+	data, err := ReadFile(location, s.Key)
+	if err != nil {
+		return err
+	}
+	warnings, err := decodeSession(data, s)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Session file %s %s.", location, err))
+	}
+	s.loadWarnings = warnings
+	return nil
+}
+
+// LoadWarnings returns what couldn't be recovered from the session file
+// loaded with LoadFromFile, if it was written by an older version of Gitrob
+func (s *Session) LoadWarnings() []string {
+	return s.loadWarnings
+}
+
 func NewSession() (*Session, error) {
 	var err error
 	var session Session
@@ -237,21 +595,34 @@ func NewSession() (*Session, error) {
 		return nil, errors.New(fmt.Sprintf("File: %s already exists.", *session.Options.Save))
 	}
 
+	if session.Key, err = LoadFileKey(*session.Options.KeyFile); err != nil {
+		return nil, err
+	}
+
 	if *session.Options.Load != "" {
-		if !FileExists(*session.Options.Load) {
-			return nil, errors.New(fmt.Sprintf("Session file %s does not exist or is not readable.", *session.Options.Load))
-		}
-		data, err := ioutil.ReadFile(*session.Options.Load)
-		if err != nil {
+		if err := session.LoadFromFile(*session.Options.Load); err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal(data, &session); err != nil {
-			return nil, errors.New(fmt.Sprintf("Session file %s is corrupt or generated by an old version of Gitrob.", *session.Options.Load))
+	} else if *session.Options.Resume != "" {
+		if err := session.Resume(*session.Options.Resume); err != nil {
+			return nil, err
 		}
 	}
 
 	session.Version = Version
+	session.SchemaVersion = SessionSchemaVersion
+	if *session.Options.Load == "" {
+		options := session.Options
+		session.ScanOptions = &options
+	}
 	session.Start()
+	loaded := *session.Options.Load
+	if loaded == "" {
+		loaded = *session.Options.Resume
+	}
+	for _, warning := range session.loadWarnings {
+		session.Out.Warn("Session file %s: %s.\n", loaded, warning)
+	}
 
 	return &session, nil
 }
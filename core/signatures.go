@@ -1,16 +1,21 @@
 package core
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
+	SnippetContext    = 2   // Lines included before and after the matched line
+	SnippetLineLength = 200 // Maximum length of a line in a snippet
+
 	TypeSimple  = "simple"
 	TypePattern = "pattern"
 	TypeContent = "content"
@@ -19,6 +24,7 @@ const (
 	PartFilename  = "filename"
 	PartPath      = "path"
 	PartContent   = "content"
+	PartKey       = "key"
 )
 
 var skippableExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tiff", ".tif", ".psd", ".xcf"}
@@ -26,60 +32,109 @@ var skippablePathIndicators = []string{"node_modules/", "vendor/bundle", "vendor
 
 // MatchFile represents a file to be analyzed
 type MatchFile struct {
-	Path      string
-	Filename  string
-	Extension string
+	Path       string
+	Filename   string
+	Extension  string
+	Content    []byte
+	Structured *StructuredContent
 }
 
 // Finding represents a security finding
 type Finding struct {
-	Id              string
-	FilePath        string
-	Action          string
-	Description     string
-	Comment         string
-	RepositoryOwner string
-	RepositoryName  string
-	CommitHash      string
-	CommitMessage   string
-	CommitAuthor    string
-	FileUrl         string
-	CommitUrl       string
-	RepositoryUrl   string
+	Id                string
+	RuleId            string
+	FilePath          string
+	LineNumber        int
+	Action            string
+	Description       string
+	Comment           string
+	Severity          string
+	RepositoryOwner   string
+	RepositoryName    string
+	CommitHash        string
+	CommitMessage     string
+	CommitAuthor      string
+	CommitDate        time.Time
+	FileUrl           string
+	CommitUrl         string
+	RepositoryUrl     string
+	Encoding          string // Encoding of the value the signature matched in, if any
+	SecretFingerprint string // Fingerprint of the matched secret, for content signatures
+	Verification      string // Result of live verification: Verified, Invalid or Unknown
+	Snippet           string // Lines around LineNumber with the secret redacted
+	Change            string `json:",omitempty"` // Change compared to a baseline scan: new, fixed or unchanged
+	TriageStatus      string `json:",omitempty"` // false_positive, accepted or fixed, or empty if open
+	Assignee          string `json:",omitempty"`
+	Note              string `json:",omitempty"`
 }
 
 // Signature interface defines methods all signatures must implement
 type Signature interface {
 	Match(file MatchFile) bool
+	Name() string
 	Description() string
 	Comment() string
+	Severity() string
+}
+
+// SecretExtractor is implemented by signatures that can return the secret
+// they matched in a file, along with the line it was found on
+type SecretExtractor interface {
+	Secret(file MatchFile) (string, int)
+}
+
+// Verifiable is implemented by signatures that can reference a verifier to
+// check whether the secrets they match are live
+type Verifiable interface {
+	Verifier() string
 }
 
 // SimpleSignature for exact matches
 type SimpleSignature struct {
+	name        string
 	part        string
 	match       string
 	description string
 	comment     string
+	severity    string
 }
 
 // PatternSignature for regex-based matches
 type PatternSignature struct {
+	name        string
 	part        string
 	match       *regexp.Regexp
 	description string
 	comment     string
+	severity    string
 }
 
 // ContentSignature for matching file contents
 type ContentSignature struct {
+	name        string
 	part        string
 	match       *regexp.Regexp
 	description string
 	comment     string
+	severity    string
+	keywords    []string
+	verifier    string
 	content     []byte
 }
 
+// KeySignature for matching values of keys in structured files
+type KeySignature struct {
+	name        string
+	part        string
+	key         *regexp.Regexp
+	match       *regexp.Regexp
+	description string
+	comment     string
+	severity    string
+	keywords    []string
+	verifier    string
+}
+
 func (f *MatchFile) IsSkippable() bool {
 	ext := strings.ToLower(f.Extension)
 	path := strings.ToLower(f.Path)
@@ -98,7 +153,7 @@ func (f *MatchFile) IsSkippable() bool {
 
 func (f *Finding) setupUrls() {
 	f.RepositoryUrl = fmt.Sprintf("https://github.com/%s/%s", f.RepositoryOwner, f.RepositoryName)
-	f.FileUrl = fmt.Sprintf("%s/blob/%s/%s", f.RepositoryUrl, f.CommitHash, f.FilePath)
+	f.FileUrl = fmt.Sprintf("%s/blob/%s/%s", f.RepositoryUrl, f.CommitHash, ArchiveOuterPath(f.FilePath))
 	f.CommitUrl = fmt.Sprintf("%s/commit/%s", f.RepositoryUrl, f.CommitHash)
 }
 
@@ -114,6 +169,22 @@ func (f *Finding) generateID() {
 	f.Id = fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// SecretKey identifies the secret of a finding across commits and scans, as
+// a fingerprint of the repository and file it's in, its rule and the secret.
+// Findings without a secret fingerprint, e.g. of sensitive file names, fall
+// back to their ID, which is bound to the commit.
+func (f *Finding) SecretKey() string {
+	if f.SecretFingerprint == "" {
+		return f.Id
+	}
+	h := sha1.New()
+	for _, part := range []string{f.RepositoryOwner, f.RepositoryName, f.FilePath, f.RuleId, f.SecretFingerprint} {
+		io.WriteString(h, part)
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("secret:%x", h.Sum(nil))
+}
+
 func (f *Finding) Initialize() {
 	f.setupUrls()
 	f.generateID()
@@ -134,6 +205,10 @@ func (s SimpleSignature) Match(file MatchFile) bool {
 	return (s.match == *haystack)
 }
 
+func (s SimpleSignature) Name() string {
+	return s.name
+}
+
 func (s SimpleSignature) Description() string {
 	return s.description
 }
@@ -142,6 +217,10 @@ func (s SimpleSignature) Comment() string {
 	return s.comment
 }
 
+func (s SimpleSignature) Severity() string {
+	return s.severity
+}
+
 func (s PatternSignature) Match(file MatchFile) bool {
 	var haystack *string
 	switch s.part {
@@ -157,6 +236,10 @@ func (s PatternSignature) Match(file MatchFile) bool {
 	return s.match.MatchString(*haystack)
 }
 
+func (s PatternSignature) Name() string {
+	return s.name
+}
+
 func (s PatternSignature) Description() string {
 	return s.description
 }
@@ -165,11 +248,22 @@ func (s PatternSignature) Comment() string {
 	return s.comment
 }
 
+func (s PatternSignature) Severity() string {
+	return s.severity
+}
+
 func (s ContentSignature) Match(file MatchFile) bool {
-	if content, err := ioutil.ReadFile(file.Path); err == nil {
-		return s.match.Match(content)
+	if file.Content == nil {
+		return false
 	}
-	return false
+	if s.match.Match(file.Content) {
+		return true
+	}
+	return file.Structured != nil && s.match.Match(file.Structured.Text)
+}
+
+func (s ContentSignature) Name() string {
+	return s.name
 }
 
 func (s ContentSignature) Description() string {
@@ -180,15 +274,366 @@ func (s ContentSignature) Comment() string {
 	return s.comment
 }
 
-func NewMatchFile(path string) MatchFile {
+func (s ContentSignature) Severity() string {
+	return s.severity
+}
+
+func (s ContentSignature) Keywords() []string {
+	return s.keywords
+}
+
+func (s ContentSignature) Verifier() string {
+	return s.verifier
+}
+
+// Secret returns the text matched by the signature's "secret" named group,
+// or the whole match if the pattern has no such group. The line is 0 if the
+// match was only found in the normalised form of a structured file.
+func (s ContentSignature) Secret(file MatchFile) (string, int) {
+	content := file.Content
+	match := s.match.FindSubmatchIndex(content)
+	line := 0
+	if match != nil {
+		line = lineNumber(content, match[0])
+	} else if file.Structured != nil {
+		content = file.Structured.Text
+		match = s.match.FindSubmatchIndex(content)
+	}
+	if match == nil {
+		return "", 0
+	}
+	if i := s.match.SubexpIndex("secret"); i > 0 && match[2*i] != -1 {
+		return string(content[match[2*i]:match[2*i+1]]), line
+	}
+	return string(content[match[0]:match[1]]), line
+}
+
+func (s KeySignature) Match(file MatchFile) bool {
+	if file.Structured == nil {
+		return false
+	}
+	for _, pair := range file.Structured.Pairs {
+		if s.matchPair(pair) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s KeySignature) Name() string {
+	return s.name
+}
+
+func (s KeySignature) Description() string {
+	return s.description
+}
+
+func (s KeySignature) Comment() string {
+	return s.comment
+}
+
+func (s KeySignature) Severity() string {
+	return s.severity
+}
+
+func (s KeySignature) Keywords() []string {
+	return s.keywords
+}
+
+func (s KeySignature) Verifier() string {
+	return s.verifier
+}
+
+// Secret returns the value of the first key matched by the signature
+func (s KeySignature) Secret(file MatchFile) (string, int) {
+	if file.Structured == nil {
+		return "", 0
+	}
+	for _, pair := range file.Structured.Pairs {
+		if s.matchPair(pair) {
+			return pair.Value, pairLine(file.Content, pair)
+		}
+	}
+	return "", 0
+}
+
+// pairLine returns the line of content the value of a key/value pair is on:
+// the first line with both the value and the last part of the key, or else
+// the line of the value if it's on only one line. It returns 0 if the line
+// can't be resolved, e.g. for values that are escaped in the file.
+func pairLine(content []byte, pair KeyValue) int {
+	key := pair.Key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	if i := strings.Index(key, "["); i >= 0 {
+		key = key[:i]
+	}
+	value := []byte(pair.Value)
+	valueLine := 0
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		if !bytes.Contains(line, value) {
+			continue
+		}
+		if key != "" && bytes.Contains(line, []byte(key)) {
+			return i + 1
+		}
+		if valueLine != 0 {
+			valueLine = -1
+		} else {
+			valueLine = i + 1
+		}
+	}
+	if valueLine < 0 {
+		return 0
+	}
+	return valueLine
+}
+
+// lineNumber returns the 1-based line of the byte offset in content, or 0
+// if the offset is negative.
+func lineNumber(content []byte, offset int) int {
+	if offset < 0 {
+		return 0
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// Snippet returns the line of the file and up to SnippetContext lines
+// around it, with secrets redacted: the matched secret, and anything else the
+// signatures match on those lines, e.g. other variables of a .env file. The
+// first line of the snippet is line-SnippetContext, or 1 if that is less.
+func Snippet(file MatchFile, line int, secret string, signatures []Signature) string {
+	if line < 1 {
+		return ""
+	}
+	lines := strings.Split(string(file.Content), "\n")
+	if line > len(lines) {
+		return ""
+	}
+	start := line - SnippetContext
+	if start < 1 {
+		start = 1
+	}
+	end := line + SnippetContext
+	if end > len(lines) {
+		end = len(lines)
+	}
+	snippet := lines[start-1 : end]
+	secrets := snippetSecrets(file, snippet, signatures)
+	if secret != "" {
+		secrets = append(secrets, secret)
+	}
+	// Longer secrets first, so a secret containing another is fully redacted
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for i, l := range snippet {
+		for _, secret := range secrets {
+			l = strings.Replace(l, secret, RedactSecret(secret), -1)
+		}
+		l = strings.TrimRight(l, "\r")
+		if len(l) > SnippetLineLength {
+			l = l[:SnippetLineLength] + "..."
+		}
+		snippet[i] = l
+	}
+	return strings.Join(snippet, "\n")
+}
+
+// snippetSecrets returns the secrets content signatures match on the lines,
+// and the values of the file key signatures match
+func snippetSecrets(file MatchFile, lines []string, signatures []Signature) []string {
+	var secrets []string
+	for _, signature := range signatures {
+		switch signature := signature.(type) {
+		case ContentSignature:
+			if signature.match == nil {
+				continue
+			}
+			group := signature.match.SubexpIndex("secret")
+			for _, line := range lines {
+				for _, match := range signature.match.FindAllStringSubmatchIndex(line, -1) {
+					if group > 0 && match[2*group] != -1 {
+						secrets = append(secrets, line[match[2*group]:match[2*group+1]])
+					} else if match[1] > match[0] {
+						secrets = append(secrets, line[match[0]:match[1]])
+					}
+				}
+			}
+		case KeySignature:
+			if file.Structured == nil {
+				continue
+			}
+			for _, pair := range file.Structured.Pairs {
+				if signature.matchPair(pair) {
+					secrets = append(secrets, pair.Value)
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// SnippetStart returns the line number of the first line of a finding's
+// snippet.
+func (f *Finding) SnippetStart() int {
+	if f.LineNumber-SnippetContext < 1 {
+		return 1
+	}
+	return f.LineNumber - SnippetContext
+}
+
+// RedactSecret keeps the first four characters of secrets long enough to
+// stay unguessable and masks the rest.
+func RedactSecret(secret string) string {
+	if len(secret) < 12 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", len(secret)-4)
+}
+
+func (s KeySignature) matchPair(pair KeyValue) bool {
+	if !s.key.MatchString(pair.Key) || !pair.IsLiteral() {
+		return false
+	}
+	return s.match == nil || s.match.MatchString(pair.Value)
+}
+
+// MatchResult holds the outcome of matching a file against signatures
+type MatchResult struct {
+	Signature Signature
+	Encoding  string // Encoding of the decoded value the signature matched in, if any
+	Secret    string // Secret matched by a content signature, if any
+	Line      int    // Line of the content the secret was found on, if known
+	Skipped   int    // Evaluations avoided by the keyword prefilter, up to the matching signature
+	TimedOut  int    // Signature evaluations given up after the timeout
+}
+
+// MatchSignatures returns the first signature matching the file, skipping
+// signatures ruled out by the prefilter. Content and key signatures that
+// haven't matched after timeout are counted as not matching; a timeout of 0
+// disables the limit.
+func MatchSignatures(file MatchFile, signatures []Signature, prefilter *Prefilter, timeout time.Duration) MatchResult {
+	var result MatchResult
+	var candidates []bool
+	if prefilter != nil && file.Content != nil {
+		if file.Structured != nil {
+			candidates = prefilter.Candidates(file.Content, file.Structured.Text)
+		} else {
+			candidates = prefilter.Candidates(file.Content)
+		}
+	}
+	for i, signature := range signatures {
+		if candidates != nil && !candidates[i] {
+			result.Skipped++
+			continue
+		}
+		matched, timedOut := matchWithTimeout(signature, file, timeout)
+		if timedOut {
+			result.TimedOut++
+		}
+		if matched {
+			result.Signature = signature
+			if extractor, ok := signature.(SecretExtractor); ok {
+				result.Secret, result.Line = extractor.Secret(file)
+			}
+			return result
+		}
+	}
+	return result
+}
+
+// Content longer than matchChunkSize is matched a chunk at a time when a
+// timeout is set, so the deadline can be checked between chunks. Chunks start
+// on a line and overlap by matchChunkOverlap bytes, so only matches spanning
+// more than that across a chunk boundary are missed.
+const (
+	matchChunkSize    = 64 * 1024
+	matchChunkOverlap = 4 * 1024
+)
+
+// boundedMatcher is implemented by signatures that can give up matching a
+// file once a deadline has passed
+type boundedMatcher interface {
+	matchUntil(file MatchFile, deadline time.Time) (matched bool, timedOut bool)
+}
+
+func matchWithTimeout(signature Signature, file MatchFile, timeout time.Duration) (bool, bool) {
+	bounded, ok := signature.(boundedMatcher)
+	if !ok || timeout <= 0 {
+		return signature.Match(file), false
+	}
+	return bounded.matchUntil(file, time.Now().Add(timeout))
+}
+
+func (s ContentSignature) matchUntil(file MatchFile, deadline time.Time) (bool, bool) {
+	if file.Content == nil {
+		return false, false
+	}
+	if matched, timedOut := matchChunks(s.match, file.Content, deadline); matched || timedOut {
+		return matched, timedOut
+	}
+	if file.Structured == nil {
+		return false, false
+	}
+	return matchChunks(s.match, file.Structured.Text, deadline)
+}
+
+func (s KeySignature) matchUntil(file MatchFile, deadline time.Time) (bool, bool) {
+	if file.Structured == nil {
+		return false, false
+	}
+	for i, pair := range file.Structured.Pairs {
+		if i%100 == 0 && time.Now().After(deadline) {
+			return false, true
+		}
+		if s.matchPair(pair) {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// matchChunks matches re against content in overlapping chunks, giving up
+// once the deadline has passed
+func matchChunks(re *regexp.Regexp, content []byte, deadline time.Time) (bool, bool) {
+	for start := 0; start < len(content); {
+		if start > 0 && time.Now().After(deadline) {
+			return false, true
+		}
+		end := start + matchChunkSize
+		if end >= len(content) {
+			return re.Match(content[start:]), false
+		}
+		if newline := bytes.IndexByte(content[end:], '\n'); newline != -1 {
+			end += newline + 1
+		} else {
+			end = len(content)
+		}
+		if re.Match(content[start:end]) {
+			return true, false
+		}
+		next := end - matchChunkOverlap
+		if newline := bytes.IndexByte(content[next:end], '\n'); newline != -1 {
+			next += newline + 1
+		}
+		if next <= start {
+			next = end
+		}
+		start = next
+	}
+	return false, false
+}
+
+func NewMatchFile(path string, content []byte) MatchFile {
 	_, filename := filepath.Split(path)
 	extension := filepath.Ext(path)
 	return MatchFile{
-		Path:      path,
-		Filename:  filename,
-		Extension: extension,
+		Path:       path,
+		Filename:   filename,
+		Extension:  extension,
+		Content:    content,
+		Structured: ExtractStructured(path, content),
 	}
 }
-
-// Signatures holds all loaded signatures from config.yaml
-var Signatures = []Signature{}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestMatchChunks(t *testing.T) {
+	line := bytes.Repeat([]byte("x"), 99)
+	filler := bytes.Repeat(append(line, '\n'), 3*matchChunkSize/100)
+	at := func(offset int, s string) []byte {
+		content := append([]byte{}, filler...)
+		return append(content[:offset], append([]byte(s+"\n"), content[offset:]...)...)
+	}
+	block := "-----BEGIN KEY-----\n" + string(bytes.Repeat([]byte("k\n"), 100)) + "-----END KEY-----"
+
+	tests := []struct {
+		name    string
+		pattern string
+		content []byte
+		matched bool
+	}{
+		{"small file", `secret`, []byte("a secret"), true},
+		{"first chunk", `secret`, at(0, "secret"), true},
+		{"last chunk", `secret`, at(len(filler)-100, "secret"), true},
+		{"across a chunk boundary", `(?s)BEGIN KEY.*END KEY`, at(matchChunkSize-100, block), true},
+		{"line anchor after a chunk boundary", `(?m)^secret$`, at((matchChunkSize/100+1)*100, "secret"), true},
+		{"text anchor after the first chunk", `\Asecret`, at(matchChunkSize+100, "secret"), false},
+		{"no match", `secret`, filler, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matched, timedOut := matchChunks(regexp.MustCompile(test.pattern), test.content, time.Now().Add(time.Minute))
+			if matched != test.matched || timedOut {
+				t.Errorf("got matched %v, timed out %v, want matched %v", matched, timedOut, test.matched)
+			}
+		})
+	}
+}
+
+func TestMatchChunksDeadline(t *testing.T) {
+	content := append(bytes.Repeat([]byte("x\n"), matchChunkSize), []byte("secret")...)
+	matched, timedOut := matchChunks(regexp.MustCompile(`secret`), content, time.Now().Add(-time.Second))
+	if matched || !timedOut {
+		t.Errorf("got matched %v, timed out %v, want a timeout", matched, timedOut)
+	}
+
+	// Files that fit in a chunk are matched whole, as there's nothing to stop between
+	matched, timedOut = matchChunks(regexp.MustCompile(`x`), []byte("x"), time.Now().Add(-time.Second))
+	if !matched || timedOut {
+		t.Errorf("got matched %v, timed out %v, want a match", matched, timedOut)
+	}
+}
+
+func TestMatchSignaturesTimeout(t *testing.T) {
+	signature := ContentSignature{match: regexp.MustCompile(`secret`), name: "secret"}
+	content := append(bytes.Repeat([]byte("x\n"), matchChunkSize), []byte("secret\n")...)
+
+	result := MatchSignatures(NewMatchFile("a.txt", content), []Signature{signature}, nil, time.Minute)
+	if result.Signature == nil || result.TimedOut != 0 {
+		t.Errorf("got signature %v and %d timeouts, want a match", result.Signature, result.TimedOut)
+	}
+	result = MatchSignatures(NewMatchFile("a.txt", content), []Signature{signature}, nil, time.Nanosecond)
+	if result.Signature != nil || result.TimedOut != 1 {
+		t.Errorf("got signature %v and %d timeouts, want a timeout", result.Signature, result.TimedOut)
+	}
+}
@@ -0,0 +1,587 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const storeOpenTimeout = time.Second
+
+var (
+	bucketOwners          = []byte("owners")
+	bucketRepositories    = []byte("repositories")
+	bucketRepositoryNames = []byte("repository_names")
+	bucketFindings        = []byte("findings")
+	bucketScans           = []byte("scans")
+	bucketScanIds         = []byte("scan_ids")
+	bucketScanIndex       = []byte("scan_index")
+	bucketTriagedSecrets  = []byte("triaged_secrets")
+
+	ErrScanNotFound = errors.New("scan not found")
+)
+
+// StoredScan is the record of a scan run in a Store
+type StoredScan struct {
+	ScanRun
+	Stats *Stats
+}
+
+// Store keeps owners, repositories, findings and scan runs in an embedded
+// bbolt database, so they survive across runs. Owners and repositories are
+// keyed by ID and findings by their ID, and every scan run indexes the
+// entities it came across.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens the database at path, creating it if it doesn't exist
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, FileMode, &bolt.Options{Timeout: storeOpenTimeout})
+	if err == bolt.ErrTimeout {
+		return nil, fmt.Errorf("database %s is in use by another process", path)
+	} else if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketOwners, bucketRepositories, bucketRepositoryNames, bucketFindings, bucketScans, bucketScanIds, bucketScanIndex, bucketTriagedSecrets} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func (st *Store) Close() error {
+	return st.db.Close()
+}
+
+// PutScan stores a scan run and its current stats. Scan runs are listed in
+// the order they were first stored.
+func (st *Store) PutScan(scan *ScanRun, stats *Stats) error {
+	data, err := marshalScan(scan, stats)
+	if err != nil {
+		return err
+	}
+	return st.db.Update(func(tx *bolt.Tx) error {
+		return putScan(tx, scan, data)
+	})
+}
+
+func marshalScan(scan *ScanRun, stats *Stats) ([]byte, error) {
+	stats.Lock()
+	defer stats.Unlock()
+	return json.Marshal(StoredScan{ScanRun: *scan, Stats: stats})
+}
+
+func putScan(tx *bolt.Tx, scan *ScanRun, data []byte) error {
+	ids := tx.Bucket(bucketScanIds)
+	key := ids.Get([]byte(scan.Id))
+	if key == nil {
+		seq, err := tx.Bucket(bucketScans).NextSequence()
+		if err != nil {
+			return err
+		}
+		key = itob(seq)
+		if err := ids.Put([]byte(scan.Id), key); err != nil {
+			return err
+		}
+	}
+	if err := tx.Bucket(bucketScans).Put(key, data); err != nil {
+		return err
+	}
+	_, err := tx.Bucket(bucketScanIndex).CreateBucketIfNotExists([]byte(scan.Id))
+	return err
+}
+
+// Scans returns all scan runs, oldest first
+func (st *Store) Scans() ([]*StoredScan, error) {
+	var scans []*StoredScan
+	err := st.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketScans).ForEach(func(k, v []byte) error {
+			var scan StoredScan
+			if err := json.Unmarshal(v, &scan); err != nil {
+				return err
+			}
+			scans = append(scans, &scan)
+			return nil
+		})
+	})
+	return scans, err
+}
+
+// LatestScan returns the most recently started scan run, or
+// ErrScanNotFound if the database is empty.
+func (st *Store) LatestScan() (*StoredScan, error) {
+	var scan StoredScan
+	err := st.db.View(func(tx *bolt.Tx) error {
+		k, v := tx.Bucket(bucketScans).Cursor().Last()
+		if k == nil {
+			return ErrScanNotFound
+		}
+		return json.Unmarshal(v, &scan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// Scan returns a scan run and its stats
+func (st *Store) Scan(id string) (*StoredScan, error) {
+	var scan StoredScan
+	err := st.db.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket(bucketScanIds).Get([]byte(id))
+		if key == nil {
+			return ErrScanNotFound
+		}
+		return json.Unmarshal(tx.Bucket(bucketScans).Get(key), &scan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// PutOwner stores an owner and indexes it for the scan run. Concurrent
+// writes of owners, repositories and findings are committed together, so
+// the workers of a scan don't each wait for a disk sync.
+func (st *Store) PutOwner(scan *ScanRun, owner *GithubOwner) error {
+	return st.db.Batch(func(tx *bolt.Tx) error {
+		return putOwner(tx, scan, owner)
+	})
+}
+
+func putOwner(tx *bolt.Tx, scan *ScanRun, owner *GithubOwner) error {
+	data, err := json.Marshal(owner)
+	if err != nil {
+		return err
+	}
+	key := itob(uint64(*owner.ID))
+	if err := tx.Bucket(bucketOwners).Put(key, data); err != nil {
+		return err
+	}
+	return indexScan(tx, scan, bucketOwners, key)
+}
+
+// PutRepository stores a repository and indexes it by full name and for the
+// scan run
+func (st *Store) PutRepository(scan *ScanRun, repository *GithubRepository) error {
+	return st.db.Batch(func(tx *bolt.Tx) error {
+		return putRepository(tx, scan, repository)
+	})
+}
+
+func putRepository(tx *bolt.Tx, scan *ScanRun, repository *GithubRepository) error {
+	data, err := json.Marshal(repository)
+	if err != nil {
+		return err
+	}
+	key := itob(uint64(*repository.ID))
+	if err := tx.Bucket(bucketRepositories).Put(key, data); err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketRepositoryNames).Put([]byte(*repository.FullName), key); err != nil {
+		return err
+	}
+	return indexScan(tx, scan, bucketRepositories, key)
+}
+
+// PutFinding stores a finding and indexes it for the scan run. Findings are keyed by ID, so a finding made again in a later scan
+// replaces the earlier one.
+func (st *Store) PutFinding(scan *ScanRun, finding *Finding) error {
+	data, err := json.Marshal(finding)
+	if err != nil {
+		return err
+	}
+	return st.db.Batch(func(tx *bolt.Tx) error {
+		return putFinding(tx, scan, finding, data)
+	})
+}
+
+func putFinding(tx *bolt.Tx, scan *ScanRun, finding *Finding, data []byte) error {
+	key := []byte(finding.Id)
+	if err := tx.Bucket(bucketFindings).Put(key, data); err != nil {
+		return err
+	}
+	if err := indexTriage(tx, finding); err != nil {
+		return err
+	}
+	return indexScan(tx, scan, bucketFindings, key)
+}
+
+// indexTriage keeps the ID of the finding last triaged for each secret, so
+// its triage is carried forward to the secret's findings in later commits
+func indexTriage(tx *bolt.Tx, finding *Finding) error {
+	if !finding.HasTriage() {
+		return nil
+	}
+	return tx.Bucket(bucketTriagedSecrets).Put([]byte(finding.SecretKey()), []byte(finding.Id))
+}
+
+// Import stores a scan run with its stats, owners, repositories and findings
+// in a single transaction
+func (st *Store) Import(scan *ScanRun, stats *Stats, owners []*GithubOwner, repositories []*GithubRepository, findings []*Finding) error {
+	data, err := marshalScan(scan, stats)
+	if err != nil {
+		return err
+	}
+	return st.db.Update(func(tx *bolt.Tx) error {
+		if err := putScan(tx, scan, data); err != nil {
+			return err
+		}
+		for _, owner := range owners {
+			if err := putOwner(tx, scan, owner); err != nil {
+				return err
+			}
+		}
+		for _, repository := range repositories {
+			if err := putRepository(tx, scan, repository); err != nil {
+				return err
+			}
+		}
+		for _, finding := range findings {
+			data, err := json.Marshal(finding)
+			if err != nil {
+				return err
+			}
+			if err := putFinding(tx, scan, finding, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Finding returns the stored finding with the given ID, or nil if there's
+// none
+func (st *Store) Finding(id string) (*Finding, error) {
+	var finding *Finding
+	err := st.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketFindings).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		finding = &Finding{}
+		return json.Unmarshal(data, finding)
+	})
+	return finding, err
+}
+
+// TriagedFinding returns the finding last triaged with the given SecretKey,
+// or nil if there's none
+func (st *Store) TriagedFinding(key string) (*Finding, error) {
+	var finding *Finding
+	err := st.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(bucketTriagedSecrets).Get([]byte(key))
+		if id == nil {
+			return nil
+		}
+		data := tx.Bucket(bucketFindings).Get(id)
+		if data == nil {
+			return nil
+		}
+		finding = &Finding{}
+		return json.Unmarshal(data, finding)
+	})
+	return finding, err
+}
+
+// UpdateFinding changes the stored finding with the given ID with fn, and
+// returns the changed finding, or nil if there's none
+func (st *Store) UpdateFinding(id string, fn func(finding *Finding)) (*Finding, error) {
+	var finding *Finding
+	err := st.db.Update(func(tx *bolt.Tx) error {
+		findings := tx.Bucket(bucketFindings)
+		data := findings.Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		finding = &Finding{}
+		if err := json.Unmarshal(data, finding); err != nil {
+			return err
+		}
+		fn(finding)
+		data, err := json.Marshal(finding)
+		if err != nil {
+			return err
+		}
+		if err := findings.Put([]byte(id), data); err != nil {
+			return err
+		}
+		return indexTriage(tx, finding)
+	})
+	return finding, err
+}
+
+// indexScan adds the key of an entity in the named bucket to the scan run's
+// index. Besides the set of keys, the index keeps the keys in the order they
+// were first added, so entities are listed in the order they were found.
+func indexScan(tx *bolt.Tx, scan *ScanRun, name []byte, key []byte) error {
+	index := tx.Bucket(bucketScanIndex).Bucket([]byte(scan.Id))
+	if index == nil {
+		return ErrScanNotFound
+	}
+	entities, err := index.CreateBucketIfNotExists(name)
+	if err != nil {
+		return err
+	}
+	if entities.Get(key) != nil {
+		return nil
+	}
+	order, err := index.CreateBucketIfNotExists(orderBucket(name))
+	if err != nil {
+		return err
+	}
+	seq, err := order.NextSequence()
+	if err != nil {
+		return err
+	}
+	if err := order.Put(itob(seq), key); err != nil {
+		return err
+	}
+	return entities.Put(key, itob(seq))
+}
+
+func orderBucket(name []byte) []byte {
+	return append(append([]byte{}, name...), "_order"...)
+}
+
+// Owners returns the owners gathered in a scan run
+func (st *Store) Owners(scanId string) ([]*GithubOwner, error) {
+	var owners []*GithubOwner
+	err := st.forEachInScan(scanId, bucketOwners, func(data []byte) error {
+		var owner GithubOwner
+		if err := json.Unmarshal(data, &owner); err != nil {
+			return err
+		}
+		owners = append(owners, &owner)
+		return nil
+	})
+	return owners, err
+}
+
+// Repositories returns the repositories gathered in a scan run
+func (st *Store) Repositories(scanId string) ([]*GithubRepository, error) {
+	var repositories []*GithubRepository
+	err := st.forEachInScan(scanId, bucketRepositories, func(data []byte) error {
+		var repository GithubRepository
+		if err := json.Unmarshal(data, &repository); err != nil {
+			return err
+		}
+		repositories = append(repositories, &repository)
+		return nil
+	})
+	return repositories, err
+}
+
+// Findings returns the findings made in a scan run
+func (st *Store) Findings(scanId string) ([]*Finding, error) {
+	var findings []*Finding
+	err := st.forEachInScan(scanId, bucketFindings, func(data []byte) error {
+		var finding Finding
+		if err := json.Unmarshal(data, &finding); err != nil {
+			return err
+		}
+		findings = append(findings, &finding)
+		return nil
+	})
+	return findings, err
+}
+
+// QueryFindings returns the page of the findings of a scan run that match
+// the query. Pages of unfiltered findings are read from the position of the
+// cursor in the scan run's index, without reading the findings before them.
+// Other queries read all findings of the scan run, keeping those that match.
+func (st *Store) QueryFindings(scanId string, q *FindingsQuery) (*FindingsPage, error) {
+	if q.Unfiltered() {
+		return st.findingsPage(scanId, q)
+	}
+	var matching []*Finding
+	total := 0
+	err := st.forEachInScan(scanId, bucketFindings, func(data []byte) error {
+		total++
+		var finding Finding
+		if err := json.Unmarshal(data, &finding); err != nil {
+			return err
+		}
+		if q.Matches(&finding) {
+			matching = append(matching, &finding)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return q.page(matching, total), nil
+}
+
+func (st *Store) findingsPage(scanId string, q *FindingsQuery) (*FindingsPage, error) {
+	page := &FindingsPage{Findings: []*Finding{}}
+	err := st.db.View(func(tx *bolt.Tx) error {
+		index := tx.Bucket(bucketScanIndex).Bucket([]byte(scanId))
+		if index == nil {
+			return ErrScanNotFound
+		}
+		order := index.Bucket(orderBucket(bucketFindings))
+		if order == nil {
+			page.NextCursor = encodeCursor(0)
+			return nil
+		}
+		// Findings are never removed from the index, so its sequence counts them
+		page.Total = int(order.Sequence())
+		page.Matching = page.Total
+		start := q.Offset
+		if start > page.Total {
+			start = page.Total
+		}
+		end := start
+		all := tx.Bucket(bucketFindings)
+		c := order.Cursor()
+		for k, v := c.Seek(itob(uint64(start + 1))); k != nil && end-start < q.Limit; k, v = c.Next() {
+			end++
+			data := all.Get(v)
+			if data == nil {
+				continue
+			}
+			var finding Finding
+			if err := json.Unmarshal(data, &finding); err != nil {
+				return err
+			}
+			page.Findings = append(page.Findings, &finding)
+		}
+		page.NextCursor = encodeCursor(end)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// forEachInScan calls fn with the stored data of every entity in the named
+// bucket that the scan run indexed, in the order they were indexed
+func (st *Store) forEachInScan(scanId string, name []byte, fn func(data []byte) error) error {
+	return st.db.View(func(tx *bolt.Tx) error {
+		index := tx.Bucket(bucketScanIndex).Bucket([]byte(scanId))
+		if index == nil {
+			return ErrScanNotFound
+		}
+		order := index.Bucket(orderBucket(name))
+		if order == nil {
+			return nil
+		}
+		all := tx.Bucket(name)
+		return order.ForEach(func(_, k []byte) error {
+			data := all.Get(k)
+			if data == nil {
+				return nil
+			}
+			return fn(data)
+		})
+	})
+}
+
+// itob encodes an ID as a big-endian key, so keys sort numerically
+func itob(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return b
+}
+
+// InitStore opens the database given with -db. A scan stores everything it
+// gathers and finds under its scan run, which continues the run of the
+// checkpoint it resumes. A loaded session file is imported as its scan run.
+// Without anything to scan or load, the latest scan run is loaded from the
+// database.
+func (s *Session) InitStore() {
+	if *s.Options.Database == "" {
+		return
+	}
+	store, err := OpenStore(*s.Options.Database)
+	if err != nil {
+		s.Out.Fatal("Failed to open database %s: %s\n", *s.Options.Database, err)
+	}
+	s.Store = store
+	if s.Key != nil {
+		s.Out.Warn("The database %s isn't encrypted. Only the current user can read it.\n", *s.Options.Database)
+	}
+
+	if *s.Options.Load != "" {
+		if err := s.importToStore(); err != nil {
+			s.Out.Fatal("Failed to import session into database %s: %s\n", *s.Options.Database, err)
+		}
+		return
+	}
+	if *s.Options.Resume == "" && *s.Options.RepoURL == "" && *s.Options.RepoListFile == "" && len(s.Options.Logins) == 0 {
+		scan, err := store.LatestScan()
+		if err != nil {
+			s.Out.Fatal("Failed to load latest scan from database %s: %s\n", *s.Options.Database, err)
+		}
+		if err := s.LoadFromStore(scan.Id); err != nil {
+			s.Out.Fatal("Failed to load scan %s from database %s: %s\n", scan.Id, *s.Options.Database, err)
+		}
+		return
+	}
+	if err := store.PutScan(s.Scan, s.Stats); err != nil {
+		s.Out.Fatal("Failed to start scan in database %s: %s\n", *s.Options.Database, err)
+	}
+}
+
+// LoadFromStore loads a scan run from the database into the session
+func (s *Session) LoadFromStore(id string) error {
+	scan, err := s.Store.Scan(id)
+	if err != nil {
+		return err
+	}
+	if s.Targets, err = s.Store.Owners(id); err != nil {
+		return err
+	}
+	if s.Repositories, err = s.Store.Repositories(id); err != nil {
+		return err
+	}
+	if s.Findings, err = s.Store.Findings(id); err != nil {
+		return err
+	}
+	s.Scan = &scan.ScanRun
+	s.Stats = scan.Stats
+	s.LoadedFromStore = true
+	return nil
+}
+
+// importToStore stores the loaded session under its scan run. Importing the
+// same session again updates the stored scan run. Findings that weren't
+// triaged in the session keep their triage in the database.
+func (s *Session) importToStore() error {
+	for _, finding := range s.Findings {
+		s.carryTriage(finding)
+	}
+	if err := s.Store.Import(s.Scan, s.Stats, s.Targets, s.Repositories, s.Findings); err != nil {
+		return err
+	}
+	s.Out.Important("Imported session into database %s as scan %s\n", *s.Options.Database, s.Scan.Id)
+	return nil
+}
+
+// store runs fn against the database if the session has one, and counts
+// failures as scan errors, as the stored scan is incomplete. It's called
+// without holding the session's lock, so workers don't wait on each other's
+// writes.
+func (s *Session) store(fn func(st *Store) error) {
+	if s.Store == nil || s.Scan == nil || s.LoadedFromStore {
+		return
+	}
+	if err := fn(s.Store); err != nil {
+		s.ScanError("Error writing to database %s: %s\n", *s.Options.Database, err)
+	}
+}
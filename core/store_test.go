@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStoreQueryFindings(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	store, err := OpenStore(filepath.Join(dir, "gitrob.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	scan := NewScanRun(time.Now())
+	if err := store.PutScan(scan, &Stats{}); err != nil {
+		t.Fatal(err)
+	}
+	var findings []*Finding
+	for i := 0; i < 7; i++ {
+		finding := &Finding{Id: fmt.Sprintf("f%d", i), FilePath: fmt.Sprintf("%d.env", 7-i), Severity: Severities[i%len(Severities)]}
+		if err := store.PutFinding(scan, finding); err != nil {
+			t.Fatal(err)
+		}
+		findings = append(findings, finding)
+	}
+	// Storing a finding again doesn't add it to the scan run twice
+	if err := store.PutFinding(scan, findings[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{"limit=3", "limit=3&cursor=" + encodeCursor(3), "limit=3&cursor=" + encodeCursor(6), "cursor=" + encodeCursor(100), "sort=path&limit=2", "severity=high,low", "q=nothing"} {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := ParseFindingsQuery(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.QueryFindings(scan.Id, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := q.Apply(findings); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", query, got, want)
+		}
+	}
+
+	q, _ := ParseFindingsQuery(url.Values{})
+	if _, err := store.QueryFindings("missing", q); err != ErrScanNotFound {
+		t.Errorf("got error %v, want %v", err, ErrScanNotFound)
+	}
+}
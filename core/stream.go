@@ -0,0 +1,56 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// FindingWriter writes findings as JSON Lines, one object per line, as soon
+// as they are added to the session.
+type FindingWriter struct {
+	sync.Mutex
+
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewFindingWriter opens location for appending findings, or writes to
+// standard output if location is "-".
+func NewFindingWriter(location string) (*FindingWriter, error) {
+	if location == "-" {
+		return &FindingWriter{w: os.Stdout}, nil
+	}
+	f, err := os.OpenFile(location, os.O_WRONLY|os.O_CREATE|os.O_APPEND, FileMode)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Chmod(FileMode); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &FindingWriter{w: f, closer: f}, nil
+}
+
+// Write writes the finding as a single line. Each line is written with one
+// call to the underlying writer so concurrent writers never interleave.
+func (fw *FindingWriter) Write(finding *Finding) error {
+	line, err := json.Marshal(finding)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	fw.Lock()
+	defer fw.Unlock()
+	_, err = fw.w.Write(line)
+	return err
+}
+
+func (fw *FindingWriter) Close() error {
+	if fw.closer == nil {
+		return nil
+	}
+	return fw.closer.Close()
+}
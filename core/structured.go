@@ -0,0 +1,244 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FormatNotebook   = "notebook"
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatTOML       = "toml"
+	FormatProperties = "properties"
+)
+
+var structuredFormats = map[string]string{
+	".ipynb":      FormatNotebook,
+	".json":       FormatJSON,
+	".yaml":       FormatYAML,
+	".yml":        FormatYAML,
+	".toml":       FormatTOML,
+	".properties": FormatProperties,
+	".env":        FormatProperties,
+}
+
+// Values that refer to a secret kept elsewhere rather than containing it,
+// e.g. ${DB_PASSWORD}, $DB_PASSWORD, {{ .Values.password }} or %DB_PASSWORD%
+var placeholderValueRegex = regexp.MustCompile(`^(?:\$\{[^}]*\}|\$\(?[A-Za-z_][A-Za-z0-9_]*\)?|\{\{.*\}\}|%[A-Za-z_][A-Za-z0-9_]*%|<[^>]*>|null|nil|none|~)$`)
+
+// KeyValue represents a single leaf value in a structured file. Key is the
+// dotted path to the value, e.g. spring.datasource.password or
+// services.db.environment[0].
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// StructuredContent is the normalised form of a structured file
+type StructuredContent struct {
+	Format string
+	Pairs  []KeyValue
+	Text   []byte
+}
+
+// IsLiteral reports whether the value is a non-empty literal rather than a
+// placeholder or reference to a value kept elsewhere.
+func (kv KeyValue) IsLiteral() bool {
+	value := strings.TrimSpace(kv.Value)
+	if value == "" {
+		return false
+	}
+	return !placeholderValueRegex.MatchString(strings.ToLower(value))
+}
+
+// StructuredFormat returns the structured format of the file at path, or an
+// empty string if the file is not in a supported format.
+func StructuredFormat(path string) string {
+	return structuredFormats[strings.ToLower(filepath.Ext(path))]
+}
+
+// ExtractStructured parses JSON, YAML, TOML and properties files into
+// key/value pairs and a plain text rendering with any format specific
+// escaping removed. Jupyter notebooks are only rendered as the text of
+// their cells and outputs, without pairs. It returns nil if the file is
+// not in a supported format or cannot be parsed.
+func ExtractStructured(path string, content []byte) *StructuredContent {
+	format := StructuredFormat(path)
+	if format == "" || len(content) == 0 {
+		return nil
+	}
+
+	var pairs []KeyValue
+	var text []byte
+	var err error
+	switch format {
+	case FormatNotebook:
+		text, err = extractNotebook(content)
+	case FormatJSON:
+		var data interface{}
+		if err = json.Unmarshal(content, &data); err == nil {
+			pairs = flattenValue("", data, pairs)
+		}
+	case FormatYAML:
+		pairs, err = extractYAML(content)
+	case FormatTOML:
+		var data map[string]interface{}
+		if err = toml.Unmarshal(content, &data); err == nil {
+			pairs = flattenValue("", data, pairs)
+		}
+	case FormatProperties:
+		pairs = extractProperties(content)
+	}
+	if err != nil {
+		return nil
+	}
+
+	if text == nil {
+		var buf bytes.Buffer
+		for _, pair := range pairs {
+			fmt.Fprintf(&buf, "%s = %s\n", pair.Key, pair.Value)
+		}
+		text = buf.Bytes()
+	}
+	return &StructuredContent{
+		Format: format,
+		Pairs:  pairs,
+		Text:   text,
+	}
+}
+
+func extractNotebook(content []byte) ([]byte, error) {
+	var notebook struct {
+		Cells []struct {
+			Source  interface{} `json:"source"`
+			Outputs []struct {
+				Text interface{}            `json:"text"`
+				Data map[string]interface{} `json:"data"`
+			} `json:"outputs"`
+		} `json:"cells"`
+	}
+	if err := json.Unmarshal(content, &notebook); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, cell := range notebook.Cells {
+		buf.WriteString(notebookText(cell.Source))
+		buf.WriteString("\n")
+		for _, output := range cell.Outputs {
+			buf.WriteString(notebookText(output.Text))
+			buf.WriteString(notebookText(output.Data["text/plain"]))
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Notebook sources and outputs are either a string or a list of lines
+func notebookText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		var lines []string
+		for _, line := range v {
+			if s, ok := line.(string); ok {
+				lines = append(lines, s)
+			}
+		}
+		return strings.Join(lines, "")
+	}
+	return ""
+}
+
+func extractYAML(content []byte) ([]KeyValue, error) {
+	var pairs []KeyValue
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var data interface{}
+		err := decoder.Decode(&data)
+		if err == io.EOF {
+			return pairs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		pairs = flattenValue("", data, pairs)
+	}
+}
+
+func extractProperties(content []byte) []KeyValue {
+	var pairs []KeyValue
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		i := strings.IndexAny(line, "=:")
+		if i == -1 {
+			continue
+		}
+		value := strings.TrimSpace(line[i+1:])
+		value = strings.Trim(value, `"'`)
+		pairs = append(pairs, KeyValue{
+			Key:   strings.TrimSpace(line[:i]),
+			Value: value,
+		})
+	}
+	return pairs
+}
+
+func flattenValue(key string, value interface{}, pairs []KeyValue) []KeyValue {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pairs = flattenValue(joinKey(key, k), v[k], pairs)
+		}
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(v))
+		values := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			ks := fmt.Sprintf("%v", k)
+			keys = append(keys, ks)
+			values[ks] = val
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pairs = flattenValue(joinKey(key, k), values[k], pairs)
+		}
+	case []interface{}:
+		for i, val := range v {
+			pairs = flattenValue(fmt.Sprintf("%s[%d]", key, i), val, pairs)
+		}
+	case nil:
+		pairs = append(pairs, KeyValue{Key: key})
+	default:
+		pairs = append(pairs, KeyValue{Key: key, Value: fmt.Sprintf("%v", v)})
+	}
+	return pairs
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
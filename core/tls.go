@@ -0,0 +1,98 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const SelfSignedValidity = 365 * 24 * time.Hour
+
+// LoadTLSConfig returns the TLS config of the web server with the
+// certificate and key in the given files, or a generated self-signed
+// certificate. It returns nil if TLS isn't enabled.
+func LoadTLSConfig(certFile string, keyFile string, selfSigned bool, host string) (*tls.Config, error) {
+	var certificate tls.Certificate
+	var err error
+	switch {
+	case certFile != "":
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	case selfSigned:
+		certificate, err = GenerateSelfSignedCertificate(host)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// GenerateSelfSignedCertificate generates a certificate for localhost, the
+// loopback addresses and the host the web server is bound to
+func GenerateSelfSignedCertificate(host string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{Name}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(SelfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	switch ip := net.ParseIP(host); {
+	case ip == nil:
+		if host != "" && host != "localhost" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	case ip.IsUnspecified():
+		// Bound to all interfaces, so the certificate is for the host's name
+		if hostname, err := os.Hostname(); err == nil {
+			template.DNSNames = append(template.DNSNames, hostname)
+		}
+	case !ip.IsLoopback():
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of the leaf
+// certificate, to check self-signed certificates against
+func CertificateFingerprint(certificate tls.Certificate) string {
+	sum := sha256.Sum256(certificate.Certificate[0])
+	var hexBytes []string
+	for _, b := range sum {
+		hexBytes = append(hexBytes, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(hexBytes, ":")
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Triage statuses of findings. Findings that haven't been triaged are open.
+const (
+	TriageOpen          = "open"
+	TriageFalsePositive = "false_positive"
+	TriageAccepted      = "accepted"
+	TriageFixed         = "fixed"
+)
+
+// TriageStatuses lists all triage statuses
+var TriageStatuses = []string{TriageOpen, TriageFalsePositive, TriageAccepted, TriageFixed}
+
+// TriageUpdate changes the triage of a finding. Fields left nil are kept.
+type TriageUpdate struct {
+	TriageStatus *string
+	Assignee     *string
+	Note         *string
+}
+
+// Validate checks the triage status of the update, and normalizes it so an
+// open finding has no status.
+func (u *TriageUpdate) Validate() error {
+	if u.TriageStatus == nil {
+		return nil
+	}
+	status := strings.ToLower(strings.TrimSpace(*u.TriageStatus))
+	if status == "" || status == TriageOpen {
+		status = ""
+	} else if !isTriageStatus(status) {
+		return fmt.Errorf("unknown triage status %q, must be one of: %s", *u.TriageStatus, strings.Join(TriageStatuses, ", "))
+	}
+	u.TriageStatus = &status
+	return nil
+}
+
+func (u *TriageUpdate) apply(finding *Finding) {
+	if u.TriageStatus != nil {
+		finding.TriageStatus = *u.TriageStatus
+	}
+	if u.Assignee != nil {
+		finding.Assignee = strings.TrimSpace(*u.Assignee)
+	}
+	if u.Note != nil {
+		finding.Note = *u.Note
+	}
+}
+
+func isTriageStatus(status string) bool {
+	for _, s := range TriageStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTriaged reports whether the finding was marked as a false positive,
+// accepted or fixed
+func (f *Finding) IsTriaged() bool {
+	return f.TriageStatus != "" && f.TriageStatus != TriageOpen
+}
+
+// HasTriage reports whether the finding has a triage status, assignee or note
+func (f *Finding) HasTriage() bool {
+	return f.TriageStatus != "" || f.Assignee != "" || f.Note != ""
+}
+
+// CopyTriage copies the triage status, assignee and note of another finding
+func (f *Finding) CopyTriage(from *Finding) {
+	f.TriageStatus = from.TriageStatus
+	f.Assignee = from.Assignee
+	f.Note = from.Note
+}
+
+// TriageFinding updates the triage of the finding with the given ID in the
+// session and, if there's a database, the stored finding, which later scans
+// carry the triage forward from. The session is saved to the session file it
+// is saved to, or with -save-triage to the one it was loaded from. It returns the updated finding, or nil if
+// there's no finding with the ID.
+func (s *Session) TriageFinding(id string, update TriageUpdate) (*Finding, error) {
+	s.Lock()
+	var finding *Finding
+	for _, f := range s.Findings {
+		if f.Id == id {
+			update.apply(f)
+			updated := *f
+			finding = &updated
+			break
+		}
+	}
+	s.Unlock()
+
+	if s.Store != nil {
+		stored, err := s.Store.UpdateFinding(id, update.apply)
+		if err != nil {
+			return nil, err
+		}
+		if finding == nil {
+			finding = stored
+		}
+	}
+	if finding == nil {
+		return nil, nil
+	}
+	s.Events.Publish(EventFinding, finding)
+
+	if location := s.triageLocation(); location != "" {
+		if err := s.saveTriage(location); err != nil {
+			return nil, fmt.Errorf("error saving triage to %s: %v", location, err)
+		}
+	}
+	return finding, nil
+}
+
+// saveTriage writes a snapshot of the session to location. Only the snapshot
+// is taken under the session lock, so scan workers don't wait on the write,
+// and snapshots are written in the order they're taken.
+func (s *Session) saveTriage(location string) error {
+	s.triageSave.Lock()
+	defer s.triageSave.Unlock()
+	s.Lock()
+	s.Stats.Lock()
+	data, err := json.Marshal(s)
+	s.Stats.Unlock()
+	s.Unlock()
+	if err != nil {
+		return err
+	}
+	return replaceFile(location, s.Key, data)
+}
+
+// triageLocation returns the session file triage is saved to: the file the
+// session was loaded from with -save-triage, or else the session file it is
+// saved to.
+func (s *Session) triageLocation() string {
+	if s.LoadedFromStore {
+		return ""
+	}
+	if *s.Options.Load != "" && *s.Options.SaveTriage {
+		return *s.Options.Load
+	}
+	if *s.Options.Save != "" && *s.Options.Format == ReportFormatJSON {
+		return *s.Options.Save
+	}
+	return ""
+}
+
+// InitTriage loads the triage of findings from the session file given with
+// -triage-from, to carry it forward to matching findings of this scan or the
+// loaded session.
+func (s *Session) InitTriage() {
+	if *s.Options.TriageFrom == "" {
+		return
+	}
+	earlier := &Session{Key: s.Key}
+	if err := earlier.LoadFromFile(*s.Options.TriageFrom); err != nil {
+		s.Out.Fatal("Failed to load triage: %s\n", err)
+	}
+	for _, warning := range earlier.loadWarnings {
+		s.Out.Warn("Session file %s: %s.\n", *s.Options.TriageFrom, warning)
+	}
+	s.triage = make(map[string]*Finding)
+	for _, finding := range earlier.Findings {
+		if finding.HasTriage() {
+			s.triage[finding.SecretKey()] = finding
+		}
+	}
+	for _, finding := range s.Findings {
+		s.carryTriage(finding)
+	}
+	s.Out.Debug("Loaded triage of %d findings from %s\n", len(s.triage), *s.Options.TriageFrom)
+}
+
+// carryTriage copies the triage of an earlier finding of the same secret,
+// matched by SecretKey, to a finding that hasn't been triaged, from
+// -triage-from or the database. Databases written before triage was indexed
+// by secret are looked up by the finding's ID.
+func (s *Session) carryTriage(finding *Finding) {
+	if finding.HasTriage() {
+		return
+	}
+	if earlier, ok := s.triage[finding.SecretKey()]; ok {
+		finding.CopyTriage(earlier)
+		return
+	}
+	if s.Store == nil || s.LoadedFromStore {
+		return
+	}
+	earlier, err := s.Store.TriagedFinding(finding.SecretKey())
+	if err == nil && earlier == nil {
+		earlier, err = s.Store.Finding(finding.Id)
+	}
+	if err != nil {
+		s.Out.Error("Error reading triage of finding %s from database: %s\n", finding.Id, err)
+		return
+	}
+	if earlier != nil {
+		finding.CopyTriage(earlier)
+	}
+}
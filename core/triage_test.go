@@ -0,0 +1,182 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func triagedFinding(id string, commit string, status string) *Finding {
+	return &Finding{Id: id, CommitHash: commit, RepositoryOwner: "acme", RepositoryName: "api", FilePath: ".env", RuleId: "aws_key", SecretFingerprint: "f", TriageStatus: status}
+}
+
+func TestCarryTriageFromSession(t *testing.T) {
+	earlier := triagedFinding("a", "c1", TriageFalsePositive)
+	s := &Session{triage: map[string]*Finding{earlier.SecretKey(): earlier}}
+
+	tests := []struct {
+		finding *Finding
+		want    string
+	}{
+		{triagedFinding("b", "c2", ""), TriageFalsePositive},        // same secret in a new commit
+		{triagedFinding("c", "c2", TriageAccepted), TriageAccepted}, // already triaged
+		{&Finding{Id: "d", RepositoryOwner: "acme", RepositoryName: "api", FilePath: ".env", RuleId: "aws_key", SecretFingerprint: "g"}, ""},
+	}
+	for _, tt := range tests {
+		s.carryTriage(tt.finding)
+		if tt.finding.TriageStatus != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.finding.Id, tt.finding.TriageStatus, tt.want)
+		}
+	}
+}
+
+func TestCarryTriageFromStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitrob_test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := OpenStore(filepath.Join(dir, "gitrob.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	scan := NewScanRun(time.Now())
+	if err := store.PutScan(scan, &Stats{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PutFinding(scan, triagedFinding("a", "c1", "")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.UpdateFinding("a", func(f *Finding) { f.TriageStatus = TriageFalsePositive }); err != nil {
+		t.Fatal(err)
+	}
+	// A later untriaged finding of the secret doesn't hide the triage
+	if err := store.PutFinding(scan, triagedFinding("b", "c2", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Session{Store: store}
+	finding := triagedFinding("c", "c3", "")
+	s.carryTriage(finding)
+	if finding.TriageStatus != TriageFalsePositive {
+		t.Errorf("got %q, want %q", finding.TriageStatus, TriageFalsePositive)
+	}
+}
+
+func TestMergeSessionsKeepsInputs(t *testing.T) {
+	first := &Session{Findings: []*Finding{triagedFinding("a", "c1", "")}}
+	second := &Session{Findings: []*Finding{triagedFinding("a", "c1", TriageFixed)}}
+	merged, _ := MergeSessions([]*Session{first, second}, []string{"first", "second"})
+	if len(merged.Findings) != 1 || merged.Findings[0].TriageStatus != TriageFixed {
+		t.Fatalf("duplicate finding didn't keep its triage: %+v", merged.Findings)
+	}
+	if first.Findings[0].TriageStatus != "" {
+		t.Error("merging changed a finding of the merged sessions")
+	}
+}
+
+func TestTriageFindingSavesSession(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	loaded := filepath.Join(dir, "loaded.json")
+	saved := filepath.Join(dir, "saved.json")
+	original := []byte(`{"Findings":[]}`)
+
+	tests := []struct {
+		args     []string
+		location string
+	}{
+		{args: []string{"-load", loaded}},
+		{args: []string{"-load", loaded, "-save-triage"}, location: loaded},
+		{args: []string{"-save", saved}, location: saved},
+		{args: []string{"-save", saved, "-format", "sarif"}},
+	}
+	for _, test := range tests {
+		for _, location := range []string{loaded, saved} {
+			if err := ioutil.WriteFile(location, original, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		options, err := ParseOptionsFrom(flag.NewFlagSet("gitrob", flag.ContinueOnError), append([]string{"-config", "config.yaml"}, test.args...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := &Session{Options: options, Findings: []*Finding{{Id: "a"}}}
+		s.InitStats()
+		status := TriageFalsePositive
+		if _, err := s.TriageFinding("a", TriageUpdate{TriageStatus: &status}); err != nil {
+			t.Fatal(err)
+		}
+		for _, location := range []string{loaded, saved} {
+			data, err := ioutil.ReadFile(location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed := !bytes.Equal(data, original); changed != (location == test.location) {
+				t.Errorf("%q: %s changed: %v", test.args, filepath.Base(location), changed)
+			}
+		}
+	}
+}
+
+func TestTriagedFindingsInReports(t *testing.T) {
+	finding := func(id string, path string, status string, note string) *Finding {
+		f := triagedFinding(id, "c1", status)
+		f.FilePath = path
+		f.Note = note
+		return f
+	}
+	s := &Session{Findings: []*Finding{
+		finding("a", ".env", TriageFalsePositive, "Test fixture"),
+		finding("b", "config.yml", TriageAccepted, ""),
+		finding("c", "deploy.yml", TriageFixed, "Rotated"),
+		finding("d", "deploy.yml", "", ""),
+	}}
+
+	var junit bytes.Buffer
+	if err := WriteJUnitReport(&junit, s); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`tests="3" failures="1" errors="0" skipped="2"`, `<skipped message="Triaged: [`, "Triage.....: fixed"} {
+		if !strings.Contains(junit.String(), want) {
+			t.Errorf("JUnit report doesn't contain %q:\n%s", want, junit.String())
+		}
+	}
+
+	var sarif bytes.Buffer
+	if err := WriteSARIFReport(&sarif, s); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				Suppressions []sarifSuppression
+			}
+		}
+	}
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	want := []sarifSuppression{
+		{Kind: "external", Status: "underReview", Justification: "False positive: Test fixture"},
+		{Kind: "external", Status: "accepted", Justification: "Accepted risk"},
+		{Kind: "external", Status: "underReview", Justification: "Fixed: Rotated"},
+	}
+	results := log.Runs[0].Results
+	for i, suppression := range want {
+		if len(results[i].Suppressions) != 1 || results[i].Suppressions[0] != suppression {
+			t.Errorf("result %d: got suppressions %+v, want %+v", i, results[i].Suppressions, suppression)
+		}
+	}
+	if len(results[3].Suppressions) != 0 {
+		t.Errorf("open finding is suppressed")
+	}
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	VerificationVerified = "Verified"
+	VerificationInvalid  = "Invalid"
+	VerificationUnknown  = "Unknown"
+
+	DefaultVerifyThreads = 4
+	VerifyTimeout        = 10 * time.Second
+)
+
+// Verifier checks whether a secret is live against the service that issued it
+type Verifier interface {
+	Name() string
+	Verify(ctx context.Context, secret string) (string, error)
+}
+
+// VerifierConfig holds the configuration of a verifier from config.yaml
+type VerifierConfig struct {
+	BaseURL string `yaml:"base_url"`
+}
+
+var verifierFactories = map[string]func(config VerifierConfig) Verifier{
+	"github": newGithubVerifier,
+	"slack":  newSlackVerifier,
+}
+
+// VerificationPool runs verifiers for findings with bounded concurrency,
+// verifying every distinct secret at most once.
+type VerificationPool struct {
+	verifiers map[string]Verifier
+	semaphore chan struct{}
+	wg        sync.WaitGroup
+
+	sync.Mutex
+	cache map[string]*verification
+}
+
+type verification struct {
+	done   chan struct{}
+	status string
+}
+
+// NewVerificationPool creates the verifiers referenced by signatures using
+// their configuration from config.yaml. Verifiers without configuration use
+// the service's public endpoint.
+func NewVerificationPool(signatures []Signature, configs map[string]VerifierConfig, concurrency int) (*VerificationPool, error) {
+	pool := &VerificationPool{
+		verifiers: make(map[string]Verifier),
+		semaphore: make(chan struct{}, concurrency),
+		cache:     make(map[string]*verification),
+	}
+	for _, signature := range signatures {
+		v, ok := signature.(Verifiable)
+		if !ok || v.Verifier() == "" {
+			continue
+		}
+		name := v.Verifier()
+		if _, ok := pool.verifiers[name]; ok {
+			continue
+		}
+		factory, ok := verifierFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown verifier %q referenced by signature %q", name, signature.Description())
+		}
+		pool.verifiers[name] = factory(configs[name])
+	}
+	return pool, nil
+}
+
+// Submit verifies the secret in the background with the named verifier and
+// calls done with the result. Secrets already verified are not sent again.
+func (p *VerificationPool) Submit(verifier string, secret string, done func(status string)) {
+	v, ok := p.verifiers[verifier]
+	if !ok {
+		done(VerificationUnknown)
+		return
+	}
+
+	p.Lock()
+	cached, ok := p.cache[secret]
+	if !ok {
+		cached = &verification{done: make(chan struct{})}
+		p.cache[secret] = cached
+	}
+	p.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if !ok {
+			p.semaphore <- struct{}{}
+			cached.status = p.verify(v, secret)
+			<-p.semaphore
+			close(cached.done)
+		}
+		<-cached.done
+		done(cached.status)
+	}()
+}
+
+// Wait blocks until all submitted verifications have completed
+func (p *VerificationPool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *VerificationPool) verify(v Verifier, secret string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), VerifyTimeout)
+	defer cancel()
+	status, err := v.Verify(ctx, secret)
+	if err != nil {
+		return VerificationUnknown
+	}
+	return status
+}
+
+type githubVerifier struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newGithubVerifier(config VerifierConfig) Verifier {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &githubVerifier{baseURL: strings.TrimRight(baseURL, "/"), client: &http.Client{}}
+}
+
+func (v *githubVerifier) Name() string {
+	return "github"
+}
+
+func (v *githubVerifier) Verify(ctx context.Context, secret string) (string, error) {
+	req, err := http.NewRequest("GET", v.baseURL+"/user", nil)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	req.Header.Set("Authorization", "token "+secret)
+	resp, err := v.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	defer resp.Body.Close()
+	return statusFromHTTPResponse(resp), nil
+}
+
+type slackVerifier struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newSlackVerifier(config VerifierConfig) Verifier {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://slack.com"
+	}
+	return &slackVerifier{baseURL: strings.TrimRight(baseURL, "/"), client: &http.Client{}}
+}
+
+func (v *slackVerifier) Name() string {
+	return "slack"
+}
+
+func (v *slackVerifier) Verify(ctx context.Context, secret string) (string, error) {
+	form := url.Values{"token": {secret}}
+	req, err := http.NewRequest("POST", v.baseURL+"/api/auth.test", strings.NewReader(form.Encode()))
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := v.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusFromHTTPResponse(resp), nil
+	}
+
+	var result struct {
+		Ok    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return VerificationUnknown, err
+	}
+	if result.Ok {
+		return VerificationVerified, nil
+	}
+	switch result.Error {
+	case "invalid_auth", "not_authed", "account_inactive", "token_revoked", "token_expired":
+		return VerificationInvalid, nil
+	}
+	return VerificationUnknown, nil
+}
+
+func statusFromHTTPResponse(resp *http.Response) string {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return VerificationVerified
+	case resp.StatusCode == http.StatusUnauthorized:
+		return VerificationInvalid
+	}
+	return VerificationUnknown
+}
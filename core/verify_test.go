@@ -0,0 +1,138 @@
+package core
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGithubVerifier(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusOK, VerificationVerified},
+		{http.StatusUnauthorized, VerificationInvalid},
+		{http.StatusForbidden, VerificationUnknown},
+		{http.StatusInternalServerError, VerificationUnknown},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/user" || r.Header.Get("Authorization") != "token ghp_secret" {
+				t.Errorf("unexpected request %s %s with %q", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+			}
+			w.WriteHeader(tt.status)
+		}))
+		verifier := newGithubVerifier(VerifierConfig{BaseURL: server.URL + "/"})
+		pool := &VerificationPool{}
+		if got := pool.verify(verifier, "ghp_secret"); got != tt.want {
+			t.Errorf("status %d: got %s, want %s", tt.status, got, tt.want)
+		}
+		server.Close()
+	}
+}
+
+func TestSlackVerifier(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   string
+	}{
+		{http.StatusOK, `{"ok": true}`, VerificationVerified},
+		{http.StatusOK, `{"ok": false, "error": "invalid_auth"}`, VerificationInvalid},
+		{http.StatusOK, `{"ok": false, "error": "token_revoked"}`, VerificationInvalid},
+		{http.StatusOK, `{"ok": false, "error": "ratelimited"}`, VerificationUnknown},
+		{http.StatusOK, `not json`, VerificationUnknown},
+		{http.StatusUnauthorized, ``, VerificationInvalid},
+		{http.StatusBadGateway, ``, VerificationUnknown},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/api/auth.test" || r.FormValue("token") != "xoxb-secret" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		verifier := newSlackVerifier(VerifierConfig{BaseURL: server.URL})
+		pool := &VerificationPool{}
+		if got := pool.verify(verifier, "xoxb-secret"); got != tt.want {
+			t.Errorf("%d %s: got %s, want %s", tt.status, tt.body, got, tt.want)
+		}
+		server.Close()
+	}
+}
+
+func TestVerificationPoolCachesBySecret(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Authorization") == "token live" {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	signatures := []Signature{ContentSignature{name: "github_token", verifier: "github"}}
+	configs := map[string]VerifierConfig{"github": {BaseURL: server.URL}}
+	pool, err := NewVerificationPool(signatures, configs, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lock sync.Mutex
+	results := make(map[string][]string)
+	for _, secret := range []string{"live", "revoked", "live", "live", "revoked"} {
+		secret := secret
+		pool.Submit("github", secret, func(status string) {
+			lock.Lock()
+			results[secret] = append(results[secret], status)
+			lock.Unlock()
+		})
+	}
+	pool.Submit("unknown", "live", func(status string) {
+		if status != VerificationUnknown {
+			t.Errorf("unknown verifier: got %s", status)
+		}
+	})
+	pool.Wait()
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d requests, want one per distinct secret", n)
+	}
+	for secret, want := range map[string]string{"live": VerificationVerified, "revoked": VerificationInvalid} {
+		for _, got := range results[secret] {
+			if got != want {
+				t.Errorf("%s: got %s, want %s", secret, got, want)
+			}
+		}
+	}
+	if len(results["live"]) != 3 || len(results["revoked"]) != 2 {
+		t.Errorf("not every submission completed: %v", results)
+	}
+}
+
+func TestNewVerificationPoolUnknownVerifier(t *testing.T) {
+	signatures := []Signature{ContentSignature{name: "token", description: "Token", verifier: "nope"}}
+	if _, err := NewVerificationPool(signatures, nil, 1); err == nil {
+		t.Error("expected an error for an unknown verifier")
+	}
+}
+
+func TestVerifyThreadsOption(t *testing.T) {
+	for _, threads := range []string{"0", "-1"} {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		if _, err := ParseOptionsFrom(flags, []string{"-config", "config.yaml", "-verify-threads", threads}); err == nil {
+			t.Errorf("-verify-threads %s: expected an error", threads)
+		}
+	}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	if _, err := ParseOptionsFrom(flags, []string{"-config", "config.yaml", "-verify-threads", "1"}); err != nil {
+		t.Errorf("-verify-threads 1: %s", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BitThr3at/gitrob/core"
+)
+
+// RunDiff implements the diff command, which compares the findings of two
+// saved session files and reports them as new, fixed or unchanged.
+func RunDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := flags.String("format", core.ReportFormatMarkdown, "Format of the comparison: "+strings.Join(core.ReportFormats(), ", "))
+	output := flags.String("o", "", "Write the comparison to this file instead of standard output")
+	keyFile := flags.String("key", "", "age key file to decrypt the session files and encrypt the comparison with (or set "+core.PassphraseEnvVariable+")")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s diff [-format format] [-o file] old.json new.json\n\n", core.Name)
+		flags.PrintDefaults()
+	}
+	files := parseInterspersed(flags, args)
+
+	if len(files) != 2 {
+		flags.Usage()
+		return errors.New("diff needs exactly two session files: the baseline scan and the later scan")
+	}
+	writer, ok := core.ReportWriters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q. Use one of: %s", *format, strings.Join(core.ReportFormats(), ", "))
+	}
+	if *output != "" && core.FileExists(*output) {
+		return fmt.Errorf("File: %s already exists.", *output)
+	}
+
+	key, err := core.LoadFileKey(*keyFile)
+	if err != nil {
+		return err
+	}
+	baseline := &core.Session{Key: key}
+	if err := baseline.LoadFromFile(files[0]); err != nil {
+		return err
+	}
+	printLoadWarnings(files[0], baseline)
+	current := &core.Session{Key: key}
+	if err := current.LoadFromFile(files[1]); err != nil {
+		return err
+	}
+	printLoadWarnings(files[1], current)
+	if baseline.RuleSet != "" && current.RuleSet != "" && baseline.RuleSet != current.RuleSet {
+		fmt.Fprintf(os.Stderr, "Warning: %s and %s were scanned with different rules, so some findings may be new or fixed because of rule changes.\n", files[0], files[1])
+	}
+	diff := core.DiffSessions(baseline, current)
+	diff.Key = key
+
+	if *output == "" {
+		if err := writer(os.Stdout, diff); err != nil {
+			return fmt.Errorf("error writing comparison: %v", err)
+		}
+	} else {
+		if err := diff.SaveReport(*output, *format); err != nil {
+			return fmt.Errorf("error writing comparison to %s: %v", *output, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s comparison to: %s\n", *format, *output)
+	}
+	fmt.Fprintf(os.Stderr, "Compared %s to %s: %s.\n", files[0], files[1], core.DiffSummary(diff.Findings))
+	return nil
+}
+
+// parseInterspersed parses args with flags, allowing flags to follow
+// positional arguments, and returns the positional arguments.
+func parseInterspersed(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
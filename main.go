@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/BitThr3at/gitrob/core"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 var (
@@ -18,7 +22,7 @@ var (
 )
 
 func GatherTargets(sess *core.Session) {
-	sess.Stats.Status = core.StatusGathering
+	sess.Stats.SetStatus(core.StatusGathering)
 	sess.Out.Important("Gathering targets...\n")
 
 	// Handle single repository scan
@@ -26,13 +30,13 @@ func GatherTargets(sess *core.Session) {
 		parts := strings.Split(*sess.Options.RepoURL, "/")
 		if len(parts) != 2 {
 			sess.Out.Error("Invalid repository format. Use 'owner/repo' format\n")
-			os.Exit(1)
+			os.Exit(core.ExitCodeError)
 		}
 		owner := parts[0]
 		target, err := core.GetUserOrOrganization(owner, sess.GithubClient)
 		if err != nil {
 			sess.Out.Error(" Error retrieving information on %s: %s\n", owner, err)
-			os.Exit(1)
+			os.Exit(core.ExitCodeError)
 		}
 		sess.Out.Debug("%s (ID: %d) type: %s\n", *target.Login, *target.ID, *target.Type)
 		sess.AddTarget(target)
@@ -43,7 +47,7 @@ func GatherTargets(sess *core.Session) {
 	for _, login := range sess.Options.Logins {
 		target, err := core.GetUserOrOrganization(login, sess.GithubClient)
 		if err != nil {
-			sess.Out.Error(" Error retrieving information on %s: %s\n", login, err)
+			sess.ScanError(" Error retrieving information on %s: %s\n", login, err)
 			continue
 		}
 		sess.Out.Debug("%s (ID: %d) type: %s\n", *target.Login, *target.ID, *target.Type)
@@ -52,7 +56,7 @@ func GatherTargets(sess *core.Session) {
 			sess.Out.Debug("Gathering members of %s (ID: %d)...\n", *target.Login, *target.ID)
 			members, err := core.GetOrganizationMembers(target.Login, sess.GithubClient)
 			if err != nil {
-				sess.Out.Error(" Error retrieving members of %s: %s\n", *target.Login, err)
+				sess.ScanError(" Error retrieving members of %s: %s\n", *target.Login, err)
 				continue
 			}
 			for _, member := range members {
@@ -76,7 +80,7 @@ func GatherRepositories(sess *core.Session) {
 		repository, err := core.GetRepository(parts[0], repo, sess.GithubClient)
 		if err != nil {
 			sess.Out.Error(" Error retrieving repository %s: %s\n", *sess.Options.RepoURL, err)
-			os.Exit(1)
+			os.Exit(core.ExitCodeError)
 		}
 		sess.AddRepository(repository)
 		return
@@ -102,7 +106,7 @@ func GatherRepositories(sess *core.Session) {
 				}
 				repos, err := core.GetRepositoriesFromOwner(target.Login, sess.GithubClient)
 				if err != nil {
-					sess.Out.Error(" Failed to retrieve repositories from %s: %s\n", *target.Login, err)
+					sess.ScanError(" Failed to retrieve repositories from %s: %s\n", *target.Login, err)
 				}
 				if len(repos) == 0 {
 					continue
@@ -125,139 +129,257 @@ func GatherRepositories(sess *core.Session) {
 }
 
 func AnalyzeRepositories(sess *core.Session) {
-	sess.Stats.Status = core.StatusAnalyzing
-	var ch = make(chan *core.GithubRepository, len(sess.Repositories))
-	var wg sync.WaitGroup
+	sess.Stats.SetStatus(core.StatusAnalyzing)
+	// Checkpoints from here on have all targets and repositories gathered
+	sess.Checkpoint()
+
+	var repositories []*core.GithubRepository
+	for _, repo := range sess.Repositories {
+		if !sess.IsRepositoryCompleted(repo) {
+			repositories = append(repositories, repo)
+		}
+	}
 	var threadNum int
-	if len(sess.Repositories) <= 1 {
+	if len(repositories) <= 1 {
 		threadNum = 1
-	} else if len(sess.Repositories) <= *sess.Options.Threads {
-		threadNum = len(sess.Repositories) - 1
+	} else if len(repositories) <= *sess.Options.Threads {
+		threadNum = len(repositories) - 1
 	} else {
 		threadNum = *sess.Options.Threads
 	}
-	wg.Add(threadNum)
 	sess.Out.Debug("Threads for repository analysis: %d\n", threadNum)
 
-	sess.Out.Important("Analyzing %d %s...\n", len(sess.Repositories), core.Pluralize(len(sess.Repositories), "repository", "repositories"))
+	sess.Out.Important("Analyzing %d %s...\n", len(repositories), core.Pluralize(len(repositories), "repository", "repositories"))
 
-	for i := 0; i < threadNum; i++ {
-		go func(tid int) {
-			for {
-				sess.Out.Debug("[THREAD #%d] Requesting new repository to analyze...\n", tid)
-				repo, ok := <-ch
-				if !ok {
-					sess.Out.Debug("[THREAD #%d] No more tasks, marking WaitGroup as done\n", tid)
-					wg.Done()
-					return
-				}
+	pool := core.NewRepositoryPool(threadNum, AnalyzeRepository)
+	sess.Metrics.SetPool(pool)
+	pool.Analyze(sess, repositories)
+	pool.Close()
+}
 
-				sess.Out.Debug("[THREAD #%d][%s] Cloning repository...\n", tid, *repo.FullName)
-				clone, path, err := core.CloneRepository(repo.CloneURL, repo.DefaultBranch, *sess.Options.CommitDepth)
-				if err != nil {
-					if err.Error() != "remote repository is empty" {
-						sess.Out.Error("Error cloning repository %s: %s\n", *repo.FullName, err)
-					}
-					sess.Stats.IncrementRepositories()
-					sess.Stats.UpdateProgress(sess.Stats.Repositories, len(sess.Repositories))
-					continue
-				}
-				sess.Out.Debug("[THREAD #%d][%s] Cloned repository to: %s\n", tid, *repo.FullName, path)
+func AnalyzeRepository(sess *core.Session, tid int, repo *core.GithubRepository) {
+	ctx := sess.Context()
+	if *sess.Options.RepoTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *sess.Options.RepoTimeout)
+		defer cancel()
+	}
+	defer func() {
+		if ctx.Err() == context.DeadlineExceeded {
+			sess.ScanError("Analysis of repository %s timed out after %s\n", *repo.FullName, *sess.Options.RepoTimeout)
+			sess.AddTimedOutRepository(repo)
+			sess.Stats.IncrementTimedOutRepositories()
+		}
+	}()
+
+	sess.Out.Debug("[THREAD #%d][%s] Cloning repository...\n", tid, *repo.FullName)
+	var clone *git.Repository
+	var path string
+	var err error
+	cloneStart := time.Now()
+	if sess.CloneCache != nil {
+		clone, path, err = sess.CloneCache.Clone(ctx, repo, *sess.Options.CommitDepth)
+	} else {
+		clone, path, err = core.CloneRepository(ctx, repo.CloneURL, repo.DefaultBranch, *sess.Options.CommitDepth)
+	}
+	failed := err != nil && err.Error() != "remote repository is empty"
+	if ctx.Err() == nil {
+		sess.Metrics.ObserveClone(time.Since(cloneStart), failed)
+	}
+	if err != nil {
+		if failed && ctx.Err() == nil {
+			sess.ScanError("Error cloning repository %s: %s\n", *repo.FullName, err)
+		}
+		return
+	}
+	defer func() {
+		if sess.CloneCache != nil {
+			if err := sess.CloneCache.Keep(repo, path); err != nil {
+				sess.Out.Error("[THREAD #%d][%s] Error keeping clone in cache: %s\n", tid, *repo.FullName, err)
+			}
+			return
+		}
+		os.RemoveAll(path)
+		sess.Out.Debug("[THREAD #%d][%s] Deleted %s\n", tid, *repo.FullName, path)
+	}()
+	sess.Out.Debug("[THREAD #%d][%s] Cloned repository to: %s\n", tid, *repo.FullName, path)
 
-				history, err := core.GetRepositoryHistory(clone)
-				if err != nil {
-					sess.Out.Error("[THREAD #%d][%s] Error getting commit history: %s\n", tid, *repo.FullName, err)
-					os.RemoveAll(path)
-					sess.Stats.IncrementRepositories()
-					sess.Stats.UpdateProgress(sess.Stats.Repositories, len(sess.Repositories))
-					continue
-				}
-				sess.Out.Debug("[THREAD #%d][%s] Number of commits: %d\n", tid, *repo.FullName, len(history))
-
-				for _, commit := range history {
-					sess.Out.Debug("[THREAD #%d][%s] Analyzing commit: %s\n", tid, *repo.FullName, commit.Hash)
-					changes, _ := core.GetChanges(commit, clone)
-					sess.Out.Debug("[THREAD #%d][%s] Changes in %s: %d\n", tid, *repo.FullName, commit.Hash, len(changes))
-					for _, change := range changes {
-						changeAction := core.GetChangeAction(change)
-						path := core.GetChangePath(change)
-						content, err := core.GetChangeContent(change)
-						if err != nil {
-							sess.Out.Debug("[THREAD #%d][%s] Error getting content for %s: %s\n", tid, *repo.FullName, path, err)
-							continue
-						}
-
-						matchFile := core.NewMatchFile(path)
-						if matchFile.IsSkippable() {
-							sess.Out.Debug("[THREAD #%d][%s] Skipping %s\n", tid, *repo.FullName, matchFile.Path)
-							continue
-						}
-						sess.Out.Debug("[THREAD #%d][%s] Matching: %s...\n", tid, *repo.FullName, matchFile.Path)
-
-						// Create a temporary file with the content for content-based signatures
-						if content != nil {
-							tempDir, err := ioutil.TempDir("", "gitrob_content_")
-							if err != nil {
-								sess.Out.Debug("[THREAD #%d][%s] Error creating temp dir for %s: %s\n", tid, *repo.FullName, path, err)
-								continue
-							}
-							defer os.RemoveAll(tempDir) // Clean up temp dir when done
-
-							tempFile := filepath.Join(tempDir, filepath.Base(path))
-							if err := ioutil.WriteFile(tempFile, content, 0644); err == nil {
-								matchFile.Path = tempFile
-							}
-						}
-
-						for _, signature := range core.Signatures {
-							if signature.Match(matchFile) {
-								finding := &core.Finding{
-									FilePath:        path,
-									Action:          changeAction,
-									Description:     signature.Description(),
-									Comment:         signature.Comment(),
-									RepositoryOwner: *repo.Owner,
-									RepositoryName:  *repo.Name,
-									CommitHash:      commit.Hash.String(),
-									CommitMessage:   strings.TrimSpace(commit.Message),
-									CommitAuthor:    commit.Author.String(),
-								}
-								finding.Initialize()
-								sess.AddFinding(finding)
-
-								sess.Out.Warn(" %s: %s\n", strings.ToUpper(changeAction), finding.Description)
-								sess.Out.Info("  Path.......: %s\n", finding.FilePath)
-								sess.Out.Info("  Repo.......: %s\n", *repo.FullName)
-								sess.Out.Info("  Message....: %s\n", core.TruncateString(finding.CommitMessage, 100))
-								sess.Out.Info("  Author.....: %s\n", finding.CommitAuthor)
-								if finding.Comment != "" {
-									sess.Out.Info("  Comment....: %s\n", finding.Comment)
-								}
-								sess.Out.Info("  File URL...: %s\n", finding.FileUrl)
-								sess.Out.Info("  Commit URL.: %s\n", finding.CommitUrl)
-								sess.Out.Info(" ------------------------------------------------\n\n")
-								sess.Stats.IncrementFindings()
-								break
-							}
-						}
-						sess.Stats.IncrementFiles()
-					}
-					sess.Stats.IncrementCommits()
-					sess.Out.Debug("[THREAD #%d][%s] Done analyzing changes in %s\n", tid, *repo.FullName, commit.Hash)
+	history, err := core.GetRepositoryHistory(clone)
+	if err != nil {
+		sess.ScanError("[THREAD #%d][%s] Error getting commit history: %s\n", tid, *repo.FullName, err)
+		return
+	}
+	sess.Out.Debug("[THREAD #%d][%s] Number of commits: %d\n", tid, *repo.FullName, len(history))
+
+	for _, commit := range history {
+		if ctx.Err() != nil {
+			return
+		}
+		sess.Out.Debug("[THREAD #%d][%s] Analyzing commit: %s\n", tid, *repo.FullName, commit.Hash)
+		changes, err := core.GetChanges(ctx, commit, clone)
+		if err != nil && ctx.Err() == nil {
+			sess.ScanError("[THREAD #%d][%s] Error getting changes in %s: %s\n", tid, *repo.FullName, commit.Hash, err)
+		}
+		sess.Out.Debug("[THREAD #%d][%s] Changes in %s: %d\n", tid, *repo.FullName, commit.Hash, len(changes))
+		for _, change := range changes {
+			if ctx.Err() != nil {
+				return
+			}
+			changeAction := core.GetChangeAction(change)
+			path := core.GetChangePath(change)
+			content, err := core.GetChangeContent(change, *sess.Options.MaxFileSize)
+			if err == core.ErrFileTooLarge {
+				sess.Out.Debug("[THREAD #%d][%s] Not matching content of %s: %s\n", tid, *repo.FullName, path, err)
+				// Only files whose content would have been matched count as skipped
+				if file := core.NewMatchFile(path, nil); !file.IsSkippable() {
+					SkipFile(sess, repo, commit, path, err.Error())
 				}
-				sess.Out.Debug("[THREAD #%d][%s] Done analyzing commits\n", tid, *repo.FullName)
-				os.RemoveAll(path)
-				sess.Out.Debug("[THREAD #%d][%s] Deleted %s\n", tid, *repo.FullName, path)
-				sess.Stats.IncrementRepositories()
-				sess.Stats.UpdateProgress(sess.Stats.Repositories, len(sess.Repositories))
+			} else if err != nil {
+				sess.Out.Debug("[THREAD #%d][%s] Error getting content for %s: %s\n", tid, *repo.FullName, path, err)
+				continue
+			}
+			AnalyzeFile(sess, tid, repo, commit, changeAction, path, content)
+
+			if *sess.Options.ArchiveDepth > 0 && changeAction != "Delete" && core.IsArchive(path) {
+				AnalyzeArchive(sess, tid, repo, commit, change, changeAction, path)
 			}
-		}(i)
+		}
+		sess.Stats.IncrementCommits()
+		sess.Out.Debug("[THREAD #%d][%s] Done analyzing changes in %s\n", tid, *repo.FullName, commit.Hash)
 	}
-	for _, repo := range sess.Repositories {
-		ch <- repo
+	sess.Out.Debug("[THREAD #%d][%s] Done analyzing commits\n", tid, *repo.FullName)
+}
+
+func SkipFile(sess *core.Session, repo *core.GithubRepository, commit *object.Commit, path string, reason string) {
+	sess.AddSkippedFile(&core.SkippedFile{
+		RepositoryOwner: *repo.Owner,
+		RepositoryName:  *repo.Name,
+		CommitHash:      commit.Hash.String(),
+		FilePath:        path,
+		Reason:          reason,
+	})
+	sess.Stats.IncrementSkippedFiles()
+}
+
+func AnalyzeFile(sess *core.Session, tid int, repo *core.GithubRepository, commit *object.Commit, changeAction string, path string, content []byte) {
+	matchFile := core.NewMatchFile(path, content)
+	if matchFile.IsSkippable() {
+		sess.Out.Debug("[THREAD #%d][%s] Skipping %s\n", tid, *repo.FullName, matchFile.Path)
+		return
+	}
+	sess.Out.Debug("[THREAD #%d][%s] Matching: %s...\n", tid, *repo.FullName, matchFile.Path)
+
+	result := core.MatchSignatures(matchFile, sess.Signatures, sess.Prefilter, *sess.Options.RegexTimeout)
+	if result.Signature == nil && *sess.Options.Decode {
+		decoded := core.MatchEncoded(matchFile, sess.Signatures, sess.Prefilter, *sess.Options.RegexTimeout, *sess.Options.DecodeMinLength)
+		decoded.Skipped += result.Skipped
+		decoded.TimedOut += result.TimedOut
+		result = decoded
+	}
+	if result.Skipped > 0 {
+		sess.Stats.AddSkippedRegexes(result.Skipped)
+	}
+	if result.TimedOut > 0 {
+		sess.Out.Debug("[THREAD #%d][%s] %d %s timed out on %s\n", tid, *repo.FullName, result.TimedOut, core.Pluralize(result.TimedOut, "signature", "signatures"), path)
+		sess.Stats.AddTimedOutRegexes(result.TimedOut)
+	}
+
+	if signature := result.Signature; signature != nil {
+		finding := &core.Finding{
+			FilePath:        path,
+			Action:          changeAction,
+			Description:     signature.Description(),
+			Comment:         signature.Comment(),
+			Severity:        signature.Severity(),
+			RepositoryOwner: *repo.Owner,
+			RepositoryName:  *repo.Name,
+			CommitHash:      commit.Hash.String(),
+			CommitMessage:   strings.TrimSpace(commit.Message),
+			CommitAuthor:    commit.Author.String(),
+			CommitDate:      commit.Author.When,
+			Encoding:        result.Encoding,
+			RuleId:          signature.Name(),
+			LineNumber:      result.Line,
+		}
+		if result.Secret != "" {
+			finding.SecretFingerprint = core.SecretFingerprint(sess.FingerprintKey, result.Secret)
+		}
+		// Encoded matches are left without a snippet, as the encoded secret
+		// can't be redacted without decoding the line
+		if result.Encoding == "" {
+			finding.Snippet = core.Snippet(matchFile, result.Line, result.Secret, sess.Signatures)
+		}
+		finding.Initialize()
+		sess.AddFinding(finding)
+		VerifyFinding(sess, finding, signature, result.Secret)
+
+		sess.Out.Warn(" %s: %s\n", strings.ToUpper(changeAction), finding.Description)
+		sess.Out.Info("  Path.......: %s\n", finding.FilePath)
+		sess.Out.Info("  Repo.......: %s\n", *repo.FullName)
+		sess.Out.Info("  Message....: %s\n", core.TruncateString(finding.CommitMessage, 100))
+		sess.Out.Info("  Author.....: %s\n", finding.CommitAuthor)
+		if finding.Comment != "" {
+			sess.Out.Info("  Comment....: %s\n", finding.Comment)
+		}
+		if finding.Encoding != "" {
+			sess.Out.Info("  Encoding...: %s\n", finding.Encoding)
+		}
+		sess.Out.Info("  File URL...: %s\n", finding.FileUrl)
+		sess.Out.Info("  Commit URL.: %s\n", finding.CommitUrl)
+		sess.Out.Info(" ------------------------------------------------\n\n")
+		sess.Stats.IncrementFindings()
+	}
+	sess.Stats.IncrementFiles()
+}
+
+func VerifyFinding(sess *core.Session, finding *core.Finding, signature core.Signature, secret string) {
+	if sess.Verifiers == nil || secret == "" {
+		return
+	}
+	verifiable, ok := signature.(core.Verifiable)
+	if !ok || verifiable.Verifier() == "" {
+		return
+	}
+	sess.Verifiers.Submit(verifiable.Verifier(), secret, func(status string) {
+		sess.SetVerification(finding, status)
+		if status == core.VerificationVerified {
+			sess.Out.Warn(" VERIFIED: %s is live (%s/%s: %s)\n", finding.Description, finding.RepositoryOwner, finding.RepositoryName, finding.FilePath)
+		} else {
+			sess.Out.Debug("Verification of %s in %s: %s\n", finding.Description, finding.FilePath, status)
+		}
+	})
+}
+
+func AnalyzeArchive(sess *core.Session, tid int, repo *core.GithubRepository, commit *object.Commit, change *object.Change, changeAction string, path string) {
+	blob, err := core.GetChangeBlob(change, *sess.Options.ArchiveMaxSize)
+	if err == core.ErrFileTooLarge {
+		sess.Out.Debug("[THREAD #%d][%s] Skipping archive %s: %s\n", tid, *repo.FullName, path, err)
+		SkipFile(sess, repo, commit, path, "archive exceeds maximum size")
+		return
+	}
+	if err != nil || blob == nil {
+		sess.Out.Debug("[THREAD #%d][%s] Error reading archive %s: %s\n", tid, *repo.FullName, path, err)
+		return
+	}
+
+	entries, err := core.ExtractArchive(path, blob, core.ArchiveLimits{
+		Depth:   *sess.Options.ArchiveDepth,
+		MaxSize: *sess.Options.ArchiveMaxSize,
+	})
+	if err == core.ErrArchiveSizeExceeded {
+		SkipFile(sess, repo, commit, path, "archive only partially extracted: "+err.Error())
+	} else if err != nil {
+		sess.Out.Debug("[THREAD #%d][%s] Error extracting archive %s: %s\n", tid, *repo.FullName, path, err)
+	}
+	sess.Out.Debug("[THREAD #%d][%s] Entries in %s: %d\n", tid, *repo.FullName, path, len(entries))
+	for _, entry := range entries {
+		if entry.Err != nil {
+			sess.Out.Debug("[THREAD #%d][%s] Error reading %s: %s\n", tid, *repo.FullName, entry.Path, entry.Err)
+			SkipFile(sess, repo, commit, entry.Path, "archive entry unreadable: "+entry.Err.Error())
+			continue
+		}
+		AnalyzeFile(sess, tid, repo, commit, changeAction, entry.Path, entry.Content)
 	}
-	close(ch)
-	wg.Wait()
 }
 
 func PrintSessionStats(sess *core.Session) {
@@ -265,7 +387,18 @@ func PrintSessionStats(sess *core.Session) {
 	sess.Out.Info("Files.......: %d\n", sess.Stats.Files)
 	sess.Out.Info("Commits.....: %d\n", sess.Stats.Commits)
 	sess.Out.Info("Repositories: %d\n", sess.Stats.Repositories)
-	sess.Out.Info("Targets.....: %d\n\n", sess.Stats.Targets)
+	sess.Out.Info("Targets.....: %d\n", sess.Stats.Targets)
+	if sess.Stats.SkippedFiles > 0 {
+		sess.Out.Info("Skipped.....: %d %s\n", sess.Stats.SkippedFiles, core.Pluralize(sess.Stats.SkippedFiles, "file", "files"))
+	}
+	if sess.Stats.TimedOutRepositories > 0 {
+		sess.Out.Info("Timed out...: %d %s\n", sess.Stats.TimedOutRepositories, core.Pluralize(sess.Stats.TimedOutRepositories, "repository", "repositories"))
+	}
+	if sess.Stats.Errors > 0 {
+		sess.Out.Info("Errors......: %d\n", sess.Stats.Errors)
+	}
+	sess.Out.Info("\n")
+	sess.Out.Debug("Regex evaluations skipped by keyword prefilter: %d\n\n", sess.Stats.SkippedRegexes)
 }
 
 // Add this new function to handle repo list
@@ -276,8 +409,15 @@ func GatherTargetsFromRepoList(sess *core.Session) error {
 		return fmt.Errorf("failed to read repo list file: %v", err)
 	}
 
-	// Split into lines and process each repo
-	repos := strings.Split(string(content), "\n")
+	GatherRepositoriesByName(sess, strings.Split(string(content), "\n"))
+	if len(sess.Repositories) == 0 {
+		return fmt.Errorf("no valid repositories found in the list file")
+	}
+	return nil
+}
+
+// GatherRepositoriesByName adds the repositories given in owner/repo format
+func GatherRepositoriesByName(sess *core.Session, repos []string) {
 	for _, repoPath := range repos {
 		// Skip empty lines
 		repoPath = strings.TrimSpace(repoPath)
@@ -288,7 +428,7 @@ func GatherTargetsFromRepoList(sess *core.Session) error {
 		// Split into owner/repo
 		parts := strings.Split(repoPath, "/")
 		if len(parts) != 2 {
-			sess.Out.Error("Invalid repository format for %s. Skipping. Use 'owner/repo' format\n", repoPath)
+			sess.ScanError("Invalid repository format for %s. Skipping. Use 'owner/repo' format\n", repoPath)
 			continue
 		}
 
@@ -298,74 +438,110 @@ func GatherTargetsFromRepoList(sess *core.Session) error {
 		// Get the specific repository
 		repo, err := core.GetRepository(owner, repoName, sess.GithubClient)
 		if err != nil {
-			sess.Out.Error(" Error retrieving repository %s: %s\n", repoPath, err)
+			sess.ScanError(" Error retrieving repository %s: %s\n", repoPath, err)
 			continue
 		}
 		sess.Out.Debug(" Retrieved repository: %s\n", *repo.FullName)
 		sess.AddRepository(repo)
 	}
-
-	if len(sess.Repositories) == 0 {
-		return fmt.Errorf("no valid repositories found in the list file")
-	}
-	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		if err := RunReport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(core.ExitCodeError)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := RunDiff(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(core.ExitCodeError)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		if err := RunMerge(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(core.ExitCodeError)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := RunServe(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(core.ExitCodeError)
+		}
+		return
+	}
+
 	if sess, err = core.NewSession(); err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(core.ExitCodeError)
 	}
 
 	sess.Out.Info("%s\n\n", core.ASCIIBanner)
 	sess.Out.Important("%s v%s started at %s\n", core.Name, core.Version, sess.Stats.StartedAt.Format(time.RFC3339))
-	sess.Out.Important("Loaded %d signatures\n", len(core.Signatures))
+	sess.Out.Important("Loaded %d signatures\n", len(sess.Signatures))
 	if !*sess.Options.NoWebServer {
-		sess.Out.Important("Web interface available at http://%s:%d\n", *sess.Options.BindAddress, *sess.Options.Port)
+		sess.Out.Important("Web interface available at %s\n", sess.WebURL())
 	}
 
-	if sess.Stats.Status == "finished" {
-		sess.Out.Important("Loaded session file: %s\n", *sess.Options.Load)
+	if sess.Stats.Status == core.StatusFinished || sess.LoadedFromStore {
+		if sess.LoadedFromStore {
+			sess.Out.Important("Loaded scan %s from database: %s\n", sess.Scan.Id, *sess.Options.Database)
+		} else if *sess.Options.Resume != "" {
+			sess.Out.Important("Scan in checkpoint %s already finished\n", *sess.Options.Resume)
+		} else {
+			sess.Out.Important("Loaded session file: %s\n", *sess.Options.Load)
+		}
 	} else {
 		// Check which mode we're running in
-		if *sess.Options.RepoListFile != "" {
+		if sess.Stats.Status == core.StatusAnalyzing {
+			// Targets and repositories were gathered before the checkpoint
+			sess.Out.Important("Resuming scan from %s with %d of %d %s analyzed\n", *sess.Options.Resume, len(sess.CompletedRepositories), len(sess.Repositories), core.Pluralize(len(sess.Repositories), "repository", "repositories"))
+		} else if *sess.Options.RepoListFile != "" {
 			// Process repositories from list file
 			if err := GatherTargetsFromRepoList(sess); err != nil {
 				sess.Out.Fatal("%v\n", err)
 			}
 			// Skip GatherRepositories since we already have the specific repos
-			AnalyzeRepositories(sess)
-			sess.Finish()
-		} else if *sess.Options.RepoURL != "" {
-			GatherTargets(sess)
-			GatherRepositories(sess)
-			AnalyzeRepositories(sess)
-			sess.Finish()
-		} else if len(sess.Options.Logins) > 0 {
+		} else if *sess.Options.RepoURL != "" || len(sess.Options.Logins) > 0 {
 			GatherTargets(sess)
 			GatherRepositories(sess)
-			AnalyzeRepositories(sess)
-			sess.Finish()
 		} else {
 			sess.Out.Fatal("Please provide either a repository with -repo flag, a repo list file with -repo-list, or at least one GitHub organization/user\n")
 		}
+		AnalyzeRepositories(sess)
+		sess.Finish()
+	}
 
-		if *sess.Options.Save != "" {
-			err := sess.SaveToFile(*sess.Options.Save)
-			if err != nil {
-				sess.Out.Error("Error saving session to %s: %s\n", *sess.Options.Save, err)
-			}
+	if *sess.Options.Save != "" {
+		err := sess.SaveReport(*sess.Options.Save, *sess.Options.Format)
+		if err != nil {
+			sess.ScanError("Error saving session to %s: %s\n", *sess.Options.Save, err)
+		} else {
 			sess.Out.Important("Saved session to: %s\n\n", *sess.Options.Save)
 		}
 	}
 
 	PrintSessionStats(sess)
 
+	if above, count := sess.FindingsAboveThreshold(); above {
+		sess.Out.Error("%d %s above the threshold, exiting with code %d\n", count, core.Pluralize(count, "finding", "findings"), core.ExitCodeFindings)
+	} else if sess.Stats.Errors > 0 {
+		sess.Out.Error("Scan incomplete due to %d %s, exiting with code %d\n", sess.Stats.Errors, core.Pluralize(sess.Stats.Errors, "error", "errors"), core.ExitCodeError)
+	}
+
 	if *sess.Options.NoWebServer {
 		// Exit immediately if web server is disabled
-		os.Exit(0)
+		os.Exit(sess.ExitCode())
 	} else {
 		sess.Out.Important("Press Ctrl+C to stop web server and exit.\n\n")
-		select {}
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		os.Exit(sess.ExitCode())
 	}
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/BitThr3at/gitrob/core"
+)
+
+// RunMerge implements the merge command, which combines several saved
+// session files into one that can be loaded with -load.
+func RunMerge(args []string) error {
+	flags := flag.NewFlagSet("merge", flag.ExitOnError)
+	output := flags.String("o", "", "Write the merged session to this file (required)")
+	keyFile := flags.String("key", "", "age key file to decrypt the session files and encrypt the merged session with (or set "+core.PassphraseEnvVariable+")")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s merge -o merged.json session.json session.json...\n\n", core.Name)
+		flags.PrintDefaults()
+	}
+	files := parseInterspersed(flags, args)
+
+	if *output == "" {
+		return errors.New("no output file. Use -o to specify the path of the merged session file")
+	}
+	if len(files) < 2 {
+		flags.Usage()
+		return errors.New("merge needs at least two session files")
+	}
+	if core.FileExists(*output) {
+		return fmt.Errorf("File: %s already exists.", *output)
+	}
+
+	key, err := core.LoadFileKey(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	var sessions []*core.Session
+	for _, file := range files {
+		sess := &core.Session{Key: key}
+		if err := sess.LoadFromFile(file); err != nil {
+			return err
+		}
+		printLoadWarnings(file, sess)
+		sessions = append(sessions, sess)
+	}
+
+	merged, warnings := core.MergeSessions(sessions, files)
+	merged.Key = key
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s.\n", warning)
+	}
+	if err := merged.SaveToFile(*output); err != nil {
+		return fmt.Errorf("error saving merged session to %s: %v", *output, err)
+	}
+	fmt.Fprintf(os.Stderr, "Merged %d sessions with %d %s in %d %s into: %s\n",
+		len(sessions),
+		merged.Stats.Findings, core.Pluralize(merged.Stats.Findings, "finding", "findings"),
+		merged.Stats.Repositories, core.Pluralize(merged.Stats.Repositories, "repository", "repositories"),
+		*output)
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BitThr3at/gitrob/core"
+)
+
+// RunReport implements the report command, which writes reports from a
+// saved session file without scanning or starting the web interface.
+func RunReport(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	load := flags.String("load", "", "Session file to write reports from (required)")
+	html := flags.String("html", "", "Write a self-contained HTML report to this file")
+	save := flags.String("save", "", "Write a report in the format given by -format to this file")
+	format := flags.String("format", core.ReportFormatMarkdown, "Format of the file written with -save: "+strings.Join(core.ReportFormats(), ", "))
+	keyFile := flags.String("key", "", "age key file to decrypt the session file and encrypt reports with (or set "+core.PassphraseEnvVariable+")")
+	configPath := flags.String("config", "", "Path to config.yaml file, to list rules without findings in reports")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s report -load session.json [-html report.html] [-save report -format format]\n\n", core.Name)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *load == "" {
+		return errors.New("a session file is required. Use -load to specify the path to a session file")
+	}
+	if *html == "" && *save == "" {
+		return errors.New("no report to write. Use -html or -save to specify an output file")
+	}
+	for _, output := range []string{*html, *save} {
+		if output != "" && core.FileExists(output) {
+			return fmt.Errorf("File: %s already exists.", output)
+		}
+	}
+
+	key, err := core.LoadFileKey(*keyFile)
+	if err != nil {
+		return err
+	}
+	sess := &core.Session{Key: key}
+	if err := sess.LoadFromFile(*load); err != nil {
+		return err
+	}
+	printLoadWarnings(*load, sess)
+	if *configPath != "" {
+		config, err := core.LoadConfig(*configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config file: %v", err)
+		}
+		sess.Config = config
+	}
+
+	if *html != "" {
+		if err := sess.SaveReport(*html, core.ReportFormatHTML); err != nil {
+			return fmt.Errorf("error writing HTML report to %s: %v", *html, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote HTML report to: %s\n", *html)
+	}
+	if *save != "" {
+		if err := sess.SaveReport(*save, *format); err != nil {
+			return fmt.Errorf("error writing report to %s: %v", *save, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s report to: %s\n", *format, *save)
+	}
+	return nil
+}
+
+// printLoadWarnings prints what couldn't be recovered from a session file
+// written by an older version of Gitrob
+func printLoadWarnings(location string, sess *core.Session) {
+	for _, warning := range sess.LoadWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s.\n", location, warning)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/BitThr3at/gitrob/core"
+)
+
+// RunServe implements the serve command, which runs an API to start, watch
+// and cancel scans. Its options are the defaults of the scans it starts.
+func RunServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	rulesDir := flags.String("rules-dir", "", "Directory of config files that scans can select as their RuleSet by name")
+	retention := flags.Duration("retention", core.DefaultScanRetention, "How long finished scans are kept in memory to be listed and fetched")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s serve -config config.yaml [-rules-dir dir] [options]\n\n", core.Name)
+		flags.PrintDefaults()
+	}
+	options, err := core.ParseOptionsFrom(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(options.Logins) > 0 || *options.RepoURL != "" || *options.RepoListFile != "" {
+		return errors.New("serve takes no targets. Start scans with POST /scans")
+	}
+	if *options.Load != "" || *options.Resume != "" || *options.Save != "" || *options.Checkpoint != "" || *options.TriageFrom != "" {
+		return errors.New("-load, -resume, -save, -checkpoint and -triage-from can't be used with serve")
+	}
+	if *options.NoWebServer {
+		return errors.New("-no-web can't be used with serve")
+	}
+	if *retention <= 0 {
+		return errors.New("-retention must be positive")
+	}
+
+	sess, err := core.NewServerSession(options)
+	if err != nil {
+		return err
+	}
+	pool := core.NewRepositoryPool(*sess.Options.Threads, AnalyzeRepository)
+	sess.Metrics.SetPool(pool)
+	service := core.NewScanService(sess, *rulesDir, pool, *retention, RunServedScan)
+	sess.StartWebServer(service.NewRouter)
+
+	sess.Out.Info("%s\n\n", core.ASCIIBanner)
+	sess.Out.Important("%s v%s started at %s\n", core.Name, core.Version, sess.Stats.StartedAt.Format(time.RFC3339))
+	sess.Out.Important("Analyzing repositories with %d %s\n", pool.Workers(), core.Pluralize(pool.Workers(), "worker", "workers"))
+	sess.Out.Important("Scan API available at %s/scans\n", sess.WebURL())
+	sess.Out.Important("Press Ctrl+C to stop the server and exit.\n\n")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	if sess.Store != nil {
+		sess.Store.Close()
+	}
+	return nil
+}
+
+// RunServedScan gathers the targets and repositories of a scan requested
+// through the API, and analyzes the repositories with the shared pool
+func RunServedScan(sess *core.Session, pool *core.RepositoryPool, request core.ScanRequest) error {
+	sess.Stats.SetStatus(core.StatusGathering)
+	if len(request.Repositories) > 0 {
+		GatherRepositoriesByName(sess, request.Repositories)
+	}
+	if len(request.Targets) > 0 {
+		GatherTargets(sess)
+		if len(sess.Targets) > 0 {
+			GatherRepositories(sess)
+		}
+	}
+	if sess.IsCancelled() {
+		return nil
+	}
+	if len(sess.Repositories) == 0 {
+		return errors.New("no repositories found")
+	}
+
+	sess.Stats.SetStatus(core.StatusAnalyzing)
+	sess.Out.Important("Analyzing %d %s of scan %s...\n", len(sess.Repositories), core.Pluralize(len(sess.Repositories), "repository", "repositories"), sess.Scan.Id)
+	pool.Analyze(sess, sess.Repositories)
+	return nil
+}
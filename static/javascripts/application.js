@@ -1,3 +1,14 @@
+// Mutating requests send back the CSRF token the server sets as a cookie
+$.ajaxPrefilter(function(options, originalOptions, xhr) {
+  if (/^(GET|HEAD|OPTIONS)$/i.test(options.type)) {
+    return;
+  }
+  var token = document.cookie.match(/(?:^|;\s*)gitrob_csrf=([^;]*)/);
+  if (token) {
+    xhr.setRequestHeader("X-CSRF-Token", decodeURIComponent(token[1]));
+  }
+});
+
 var Stats = Backbone.Model.extend({
   url: "/stats",
   defaults: {
@@ -36,7 +47,41 @@ window.stats = new Stats;
 
 var Finding = Backbone.Model.extend({
   idAttribute: "Id",
+  defaults: {
+    "TriageStatus": "",
+    "Assignee":     "",
+    "Note":         "",
+  },
   testFileIndicators: ["test", "_spec", "fixture", "mock", "stub", "fake", "demo", "sample"],
+  triageLabels: {
+    "open":           "Open",
+    "false_positive": "False positive",
+    "accepted":       "Accepted",
+    "fixed":          "Fixed",
+  },
+  triageBadgeClasses: {
+    "open":           "badge-warning",
+    "false_positive": "badge-secondary",
+    "accepted":       "badge-info",
+    "fixed":          "badge-success",
+  },
+  triageStatus: function() {
+    return this.get("TriageStatus") || "open";
+  },
+  triageLabel: function() {
+    return this.triageLabels[this.triageStatus()] || this.triageStatus();
+  },
+  triageBadgeClass: function() {
+    return this.triageBadgeClasses[this.triageStatus()] || "badge-light";
+  },
+  triage: function(attributes, success, error) {
+    this.save(attributes, {
+      patch: true,
+      wait: true,
+      success: success,
+      error: error
+    });
+  },
   shortCommitHash: function() {
     return this.get("CommitHash").substr(0, 7);
   },
@@ -68,6 +113,18 @@ var Finding = Backbone.Model.extend({
 var Findings = Backbone.Collection.extend({
   url: "/findings",
   model: Finding,
+  total: 0,
+  matching: 0,
+  nextCursor: null,
+  parse: function(response) {
+    this.total      = response.Total;
+    this.matching   = response.Matching;
+    this.nextCursor = response.NextCursor;
+    return response.Findings;
+  },
+  hasMore: function() {
+    return this.length < this.matching;
+  },
 });
 
 window.findings = new Findings();
@@ -81,7 +138,6 @@ var StatsView = Backbone.View.extend({
   initialize: function() {
     this.listenTo(this.model, "change", this.render)
     this.startDurationTicker();
-    this.startPolling();
   },
   render: function() {
     if (this.model.isFinished()) {
@@ -184,6 +240,9 @@ var FindingView = Backbone.View.extend({
     "click td.col-path a": "showFinding",
   },
   template: _.template($("#template_finding").html()),
+  initialize: function() {
+    this.listenTo(this.model, "change:TriageStatus change:Assignee", this.render);
+  },
   render: function() {
     this.$el.html(this.template(this.model.attributes)).data("finding", this.model);
     if (this.model.isTestRelated()) {
@@ -229,12 +288,21 @@ var FindingView = Backbone.View.extend({
 
 var FindingsView = Backbone.View.extend({
   collection: findings,
+  loaded: false,
+  stale: false,
   initialize: function() {
+    _.bindAll(this, "searchFindings", "loadMore", "fetchNew");
     this.listenTo(this.collection, "add", this.renderFinding);
-    this.listenTo(stats, "change:Findings", _.debounce(this.update, 500));
+    this.listenTo(this.collection, "reset", this.render);
+    this.listenTo(this.collection, "sync", this.updatePagination);
     $("#findings_search").on("keyup", _.debounce(this.searchFindings, 200));
+    $("#findings_triage_filter").on("change", this.searchFindings);
+    $("#findings_load_more").on("click", this.loadMore);
     $("#finding_modal").on("show.bs.modal", function(event) {
       $(document).on("keydown", function(e) {
+        if ($(e.target).is("input, select, textarea")) {
+          return;
+        }
         switch(e.keyCode) {
         case 37:
           var finding = findingsView.previousFinding();
@@ -261,10 +329,68 @@ var FindingsView = Backbone.View.extend({
     })
     .on("hidden.bs.modal", function(event) {
       $(document).unbind("keydown");
+      // Findings triaged out of the filter shift the pages that follow
+      if (findingsView.stale) {
+        findingsView.searchFindings();
+      }
+    });
+  },
+  // query returns the filters of the search field and triage select for the
+  // findings API
+  query: function() {
+    var query = {};
+    var needle = $.trim($("#findings_search").val());
+    var triage = $("#findings_triage_filter").val();
+    if (needle !== "") {
+      query.q = needle;
+    }
+    if (triage !== "") {
+      query.triage = triage;
+    }
+    return query;
+  },
+  searchFindings: function() {
+    this.loaded = true;
+    this.stale  = false;
+    this.collection.fetch({reset: true, data: this.query()});
+  },
+  loadMore: function() {
+    $("#findings_load_more").prop("disabled", true);
+    this.collection.fetch({
+      remove: false,
+      data: _.extend(this.query(), {cursor: this.collection.nextCursor})
     });
   },
-  update: function() {
-    this.collection.fetch();
+  // fetchNew fetches findings made since the last page was loaded. Findings
+  // after pages that haven't been loaded yet come with those pages.
+  fetchNew: function() {
+    if (!this.loaded) {
+      this.searchFindings();
+    } else if (!this.collection.hasMore()) {
+      this.loadMore();
+    }
+  },
+  startPolling: function() {
+    this.listenTo(stats, "change:Findings", _.debounce(this.fetchNew, 500));
+  },
+  render: function() {
+    this.$el.empty();
+    this.collection.each(this.renderFinding, this);
+    return this;
+  },
+  updatePagination: function() {
+    var shown = this.collection.length;
+    $("#findings_count").text("Showing " + shown.toLocaleString() + " of " + this.collection.matching.toLocaleString() + " findings");
+    $("#findings_load_more").prop("disabled", false).toggleClass("d-none", !this.collection.hasMore());
+  },
+  hideUnmatched: function(finding) {
+    var triage = $("#findings_triage_filter").val();
+    if (triage !== "" && finding.triageStatus() !== triage) {
+      this.$el.find("tr").filter(function() {
+        return $(this).data("finding") === finding;
+      }).addClass("d-none");
+      this.stale = true;
+    }
   },
   renderFinding: function(finding) {
     var findingEl = new FindingView({model: finding}).render().el;
@@ -279,23 +405,6 @@ var FindingsView = Backbone.View.extend({
   previousFinding: function() {
     return this.activeFinding().prevAll("tr").not(".d-none").first();
   },
-  searchFindings: function() {
-    var needle = $.trim($("#findings_search").val()).toLowerCase();
-    if (needle == "") {
-      $("#table_findings tbody tr").removeClass("d-none");
-      return;
-    }
-    $("#table_findings tbody tr").each(function() {
-      var path = $(this).find("td.col-path").text().toLowerCase();
-      var commit = $(this).find("td.col-commit").text().toLowerCase();
-      var repository = $(this).find("td.col-repository").text().toLowerCase();
-      if (path.indexOf(needle) > -1 || commit.indexOf(needle) > -1 || repository.indexOf(needle) > -1) {
-        $(this).removeClass("d-none");
-      } else {
-        $(this).addClass("d-none");
-      }
-    });
-  }
 });
 window.findingsView = new FindingsView({el: "#table_findings tbody"});
 
@@ -315,6 +424,7 @@ var FindingModal = Backbone.View.extend({
   events: {
     "click #finding_view_raw": "showRawContents",
     "click #finding_view_hexdump": "showHexDumpContents",
+    "submit #finding_triage_form": "saveTriage",
   },
   render: function() {
     this.$el.html(this.template(this.model.attributes));
@@ -323,6 +433,24 @@ var FindingModal = Backbone.View.extend({
     });
     return this;
   },
+  saveTriage: function(e) {
+    e.preventDefault();
+    var message = $("#finding_triage_message").removeClass("text-danger text-success").text("Saving...");
+    $("#finding_triage_save").prop("disabled", true);
+    this.model.triage({
+      TriageStatus: $("#finding_triage_status").val(),
+      Assignee:     $("#finding_triage_assignee").val(),
+      Note:         $("#finding_triage_note").val()
+    }, function(model) {
+      $("#finding_triage_save").prop("disabled", false);
+      message.addClass("text-success").text("Saved.");
+      findingsView.hideUnmatched(model);
+    }, function(model, response) {
+      $("#finding_triage_save").prop("disabled", false);
+      var error = response.responseJSON ? response.responseJSON.message : response.statusText;
+      message.addClass("text-danger").text("Failed to save triage: " + error);
+    });
+  },
   showRawContents: function() {
     $("#finding_view_raw").addClass("active");
     $("#finding_view_hexdump").removeClass("active");
@@ -397,10 +525,63 @@ var FindingModal = Backbone.View.extend({
         }, context));
       }
       worker.postMessage(data);
-    }, function() {
+    }, function(xhr) {
+      var message = "File size too large to display inline. View file on GitHub.";
+      if (xhr.status !== 422) {
+        var error = xhr.responseJSON ? xhr.responseJSON.message : xhr.statusText;
+        message = "Failed to load file contents: " + error;
+      }
       $("#modal_file_spinner_container").fadeOut("fast", function() {
-        $("#modal_file_contents_container").html("<div class='alert alert-warning' role='alert'>File size too large to display inline. View file on GitHub.</div>").fadeIn("fast");
+        $("#modal_file_contents_container").html("<div class='alert alert-warning' role='alert'>" + _.escape(message) + "</div>").fadeIn("fast");
       });
     });
   }
 });
+
+// Live updates are pushed from the server with Server-Sent Events. Browsers
+// without EventSource, or servers that don't offer /events, are polled.
+var LiveUpdates = {
+  start: function() {
+    if (!window.EventSource) {
+      this.startPolling();
+      return;
+    }
+    var connected = false;
+    var fetchNew = _.debounce(findingsView.fetchNew, 200);
+    var source = new EventSource("/events");
+    source.addEventListener("open", function() {
+      // Catch up on findings made before or while disconnected
+      connected = true;
+      findingsView.fetchNew();
+    });
+    source.addEventListener("stats", function(e) {
+      stats.set(JSON.parse(e.data));
+    });
+    source.addEventListener("phase", function(e) {
+      stats.set(JSON.parse(e.data));
+    });
+    source.addEventListener("finding", function(e) {
+      // Changes of loaded findings are applied, while new findings are
+      // fetched so the search and triage filter apply to them
+      var finding = JSON.parse(e.data);
+      if (findings.get(finding.Id)) {
+        findings.add(finding, {merge: true});
+      } else {
+        fetchNew();
+      }
+    });
+    source.addEventListener("error", _.bind(function() {
+      // The browser reconnects by itself unless the connection was refused
+      if (source.readyState === EventSource.CLOSED || !connected) {
+        source.close();
+        this.startPolling();
+      }
+    }, this));
+  },
+  startPolling: function() {
+    statsView.startPolling();
+    findingsView.startPolling();
+    stats.fetch();
+  },
+};
+LiveUpdates.start();